@@ -37,7 +37,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var outPath string
+var (
+	outPath   string
+	component string
+)
 
 // badgeCmd represents the badge command.
 var badgeCmd = &cobra.Command{
@@ -72,6 +75,13 @@ var badgeCoverageCmd = &cobra.Command{
 		if err := r.MeasureCoverage(c.Coverage.Paths, c.Coverage.Exclude); err != nil {
 			return err
 		}
+		if component != "" {
+			if !r.IsMeasuredComponentCoverage(component) {
+				return fmt.Errorf("coverage of component %s is not measured", component)
+			}
+			cp := r.ComponentCoveragePercent(component)
+			return renderBadgeWithIcon(fmt.Sprintf("coverage (%s)", component), fmt.Sprintf("%.1f%%", floor1(cp)), c.CoverageColor(cp), out)
+		}
 		cp := r.CoveragePercent()
 		return renderBadgeWithIcon("coverage", fmt.Sprintf("%.1f%%", floor1(cp)), c.CoverageColor(cp), out)
 	},
@@ -143,7 +153,7 @@ func loadConfigAndReport(cfgPath string) (*config.Config, *report.Report, error)
 		return nil, nil, err
 	}
 	c.Build()
-	r, err := report.New(c.Repository)
+	r, err := report.New(c.Repository, reportOptions(c)...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -193,6 +203,7 @@ func init() {
 	rootCmd.AddCommand(badgeCmd)
 	badgeCmd.AddCommand(badgeCoverageCmd, badgeRatioCmd, badgeTimeCmd)
 	setBadgeFlags(badgeCoverageCmd)
+	badgeCoverageCmd.Flags().StringVarP(&component, "component", "", "", "component name in the coverage.components section")
 	setBadgeFlags(badgeRatioCmd)
 	setBadgeFlags(badgeTimeCmd)
 }
@@ -41,38 +41,18 @@ func commentReport(ctx context.Context, c *config.Config, content, key string) e
 	return nil
 }
 
-func createReportContent(ctx context.Context, c *config.Config, r, rPrev *report.Report, message string, hideFooterLink bool) (string, error) {
-	repo, err := gh.Parse(c.Repository)
-	if err != nil {
-		return "", err
-	}
-	g, err := gh.New()
-	if err != nil {
-		return "", err
-	}
-	var files []*gh.PullRequestFile
-	n, err := g.DetectCurrentPullRequestNumber(ctx, repo.Owner, repo.Repo)
-	if err == nil {
-		files, err = g.FetchPullRequestFiles(ctx, repo.Owner, repo.Repo, n)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		files, err = g.FetchChangedFiles(ctx, repo.Owner, repo.Repo)
-		if err != nil {
-			return "", err
-		}
-	}
+func createReportContent(c *config.Config, r, rPrev *report.Report, files []*gh.PullRequestFile, message string, hideFooterLink bool, indirectChangesMax, newlyUncoveredLinesMax int) string {
 	footer := "Reported by [octocov](https://github.com/k1LoW/octocov)"
 	if hideFooterLink {
 		footer = "Reported by octocov"
 	}
 	var (
-		table, fileTable string
-		customTables     []string
+		table, fileTable, indirectTable, uncoveredTable string
+		customTables                                    []string
 	)
 	if rPrev != nil {
 		d := r.Compare(rPrev)
+		d.MapLines(files)
 		table = d.Table()
 		relWd := c.Root()
 		if c.GitRoot != "" {
@@ -83,7 +63,14 @@ func createReportContent(ctx context.Context, c *config.Config, r, rPrev *report
 		if relWd == "." {
 			relWd = ""
 		}
-		fileTable = d.FileCoveragesTable(files, relWd)
+		// The files affected outside the pull request are listed in the indirect changes section if it is shown.
+		if indirectChangesMax > 0 {
+			fileTable = d.PullRequestFileCoveragesTable(files, relWd)
+		} else {
+			fileTable = d.FileCoveragesTable(files, relWd)
+		}
+		indirectTable = d.IndirectChangesTable(files, relWd, indirectChangesMax)
+		uncoveredTable = d.NewlyUncoveredLinesTable(relWd, newlyUncoveredLinesMax)
 		for _, s := range d.CustomMetrics {
 			customTables = append(customTables, s.Table(), s.MetadataTable())
 		}
@@ -112,11 +99,64 @@ func createReportContent(ctx context.Context, c *config.Config, r, rPrev *report
 	}
 	if r.IsMeasuredCoverage() || r.IsMeasuredTestExecutionTime() || r.IsMeasuredCodeToTestRatio() {
 		comment = append(comment, table, "", fileTable)
+		if indirectTable != "" {
+			comment = append(comment, indirectTable)
+		}
+		if uncoveredTable != "" {
+			comment = append(comment, uncoveredTable)
+		}
 	}
 	comment = append(comment, customTables...)
 	comment = append(comment, "---", footer)
 
-	return strings.Join(comment, "\n"), nil
+	return strings.Join(comment, "\n")
+}
+
+// fetchChangedFiles fetches the files of the current pull request, or the files changed from the default branch.
+func fetchChangedFiles(ctx context.Context, c *config.Config) ([]*gh.PullRequestFile, error) {
+	repo, err := gh.Parse(c.Repository)
+	if err != nil {
+		return nil, err
+	}
+	g, err := gh.New()
+	if err != nil {
+		return nil, err
+	}
+	n, err := g.DetectCurrentPullRequestNumber(ctx, repo.Owner, repo.Repo)
+	if err == nil {
+		return g.FetchPullRequestFiles(ctx, repo.Owner, repo.Repo, n)
+	}
+	return g.FetchChangedFiles(ctx, repo.Owner, repo.Repo)
+}
+
+// inPullRequest reports whether the current pull request is detected, that is, whether there are changes to measure patch coverage of.
+func inPullRequest(ctx context.Context, c *config.Config) bool {
+	repo, err := gh.Parse(c.Repository)
+	if err != nil {
+		return false
+	}
+	g, err := gh.New()
+	if err != nil {
+		return false
+	}
+	_, err = g.DetectCurrentPullRequestNumber(ctx, repo.Owner, repo.Repo)
+	return err == nil
+}
+
+// indirectChangesMax returns the maximum number of the files listed in the indirect coverage changes section of the comment.
+func indirectChangesMax(cc *config.Comment) int {
+	if cc.HideIndirectChanges {
+		return 0
+	}
+	return cc.IndirectChangesMax
+}
+
+// newlyUncoveredLinesMax returns the maximum number of the files listed in the newly uncovered lines section of the comment.
+func newlyUncoveredLinesMax(cc *config.Comment) int {
+	if cc.HideNewlyUncoveredLines {
+		return 0
+	}
+	return cc.NewlyUncoveredLinesMax
 }
 
 func capitalize(w string) string {
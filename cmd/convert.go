@@ -0,0 +1,90 @@
+/*
+Copyright © 2021 Ken'ichiro Oyama <k1lowxb@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/k1LoW/octocov/config"
+	"github.com/k1LoW/octocov/coverage"
+	"github.com/k1LoW/octocov/report"
+	"github.com/spf13/cobra"
+)
+
+var convertFormats = []string{"lcov", "cobertura", "sonar", "gocover"}
+
+var convertFormat string
+
+// convertCmd represents the convert command.
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "convert coverage report",
+	Long:  fmt.Sprintf("convert coverage report to another format (%s).", strings.Join(convertFormats, ", ")),
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		p, err := coverage.LookupProcessor(convertFormat)
+		if err != nil {
+			return err
+		}
+		cv, ok := p.(coverage.Converter)
+		if !ok {
+			return fmt.Errorf("cannot convert to %s", p.Name())
+		}
+		c := config.New()
+		if err := c.Load(configPath); err != nil {
+			return err
+		}
+		c.Build()
+		if reportPath != "" {
+			c.Coverage.Paths = []string{reportPath}
+		}
+		if err := c.CoverageConfigReadyOnLocal(); err != nil {
+			return err
+		}
+		r, err := report.New(c.Repository, reportOptions(c)...)
+		if err != nil {
+			return err
+		}
+		if err := r.MeasureCoverage(c.Coverage.Paths, c.Coverage.Exclude); err != nil {
+			return err
+		}
+		if r.Coverage == nil {
+			return errors.New("no coverage")
+		}
+		out, cleanup, err := openOut(outPath)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		return cv.WriteReport(out, r.Coverage)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+	convertCmd.Flags().StringVarP(&configPath, "config", "", "", "config file path")
+	convertCmd.Flags().StringVarP(&reportPath, "report", "r", "", "coverage report file path")
+	convertCmd.Flags().StringVarP(&convertFormat, "format", "f", "lcov", fmt.Sprintf("output format (%s)", strings.Join(convertFormats, ", ")))
+	convertCmd.Flags().StringVarP(&outPath, "out", "", "", "output file path")
+}
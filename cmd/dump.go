@@ -51,7 +51,7 @@ var dumpCmd = &cobra.Command{
 			c.TestExecutionTime = nil
 		}
 
-		r, err := report.New(c.Repository)
+		r, err := report.New(c.Repository, reportOptions(c)...)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2021 Ken'ichiro Oyama <k1lowxb@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+
+	"github.com/k1LoW/octocov/config"
+	"github.com/k1LoW/octocov/html"
+	"github.com/k1LoW/octocov/report"
+	"github.com/spf13/cobra"
+)
+
+var htmlOut string
+
+// htmlCmd represents the html command.
+var htmlCmd = &cobra.Command{
+	Use:   "html",
+	Short: "generate HTML code coverage report",
+	Long:  `generate HTML code coverage report: an index page with the directory tree and a page per file with annotated source.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c := config.New()
+		if err := c.Load(configPath); err != nil {
+			return err
+		}
+		c.Build()
+		if reportPath != "" {
+			c.Coverage.Paths = []string{reportPath}
+			c.CodeToTestRatio = nil
+			c.TestExecutionTime = nil
+		}
+		if c.Coverage == nil {
+			return errors.New("coverage: is not set")
+		}
+		r, err := report.New(c.Repository, reportOptions(c)...)
+		if err != nil {
+			return err
+		}
+		if err := r.MeasureCoverage(c.Coverage.Paths, c.Coverage.Exclude); err != nil {
+			return err
+		}
+		paths, err := generateHTML(c, r, htmlOut)
+		if err != nil {
+			return err
+		}
+		cmd.PrintErrf("Generated %s\n", paths[len(paths)-1])
+		return nil
+	},
+}
+
+// generateHTML generates the HTML code coverage report in dir and returns the paths of the generated files.
+// The index page comes last.
+func generateHTML(c *config.Config, r *report.Report, dir string) ([]string, error) {
+	return html.New(r, &html.Config{
+		Root:          c.GitRoot,
+		Wd:            c.Root(),
+		BySuite:       bySuite,
+		CoverageColor: c.CoverageColor,
+	}).Generate(dir)
+}
+
+func init() {
+	rootCmd.AddCommand(htmlCmd)
+	htmlCmd.Flags().StringVarP(&configPath, "config", "", "", "config file path")
+	htmlCmd.Flags().StringVarP(&reportPath, "report", "r", "", "coverage report file path")
+	htmlCmd.Flags().StringVarP(&htmlOut, "out", "o", "coverage-html", "output directory")
+	htmlCmd.Flags().BoolVarP(&bySuite, "by-suite", "", false, "show the test suites executing each line")
+}
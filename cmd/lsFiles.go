@@ -33,6 +33,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/k1LoW/octocov/config"
+	"github.com/k1LoW/octocov/coverage"
 	"github.com/k1LoW/octocov/internal"
 	"github.com/k1LoW/octocov/report"
 	"github.com/lucasb-eyer/go-colorful"
@@ -58,7 +59,7 @@ var lsFilesCmd = &cobra.Command{
 		if c.Coverage == nil {
 			return errors.New("coverage: is not set")
 		}
-		r, err := report.New(c.Repository)
+		r, err := report.New(c.Repository, reportOptions(c)...)
 		if err != nil {
 			return err
 		}
@@ -73,7 +74,7 @@ var lsFilesCmd = &cobra.Command{
 			return r.Coverage.Files[i].EffectivePath() < r.Coverage.Files[j].EffectivePath()
 		})
 
-		if len(r.Coverage.Files) == 0 {
+		if len(r.Coverage.Files) == 0 && len(r.GeneratedFiles()) == 0 {
 			return nil
 		}
 		wd, err := os.Getwd()
@@ -88,6 +89,9 @@ var lsFilesCmd = &cobra.Command{
 		for _, f := range r.Coverage.Files {
 			cfiles = append(cfiles, f.EffectivePath())
 		}
+		for _, f := range r.GeneratedFiles() {
+			cfiles = append(cfiles, f.File)
+		}
 		files, err := internal.CollectFiles(wd)
 		if err != nil {
 			return err
@@ -95,6 +99,12 @@ var lsFilesCmd = &cobra.Command{
 		slices.Sort(files)
 
 		prefix := internal.DetectPrefix(root, wd, files, cfiles)
+		if functions {
+			return printFunctions(cmd, c, r, prefix)
+		}
+		partials, wp := partialLines(r, prefix)
+		pc := color.New(color.FgYellow)
+		pc.EnableColor()
 		for _, f := range r.Coverage.Files {
 			p := filepath.Clean(f.EffectivePath())
 			if !strings.HasPrefix(p, prefix) {
@@ -111,13 +121,95 @@ var lsFilesCmd = &cobra.Command{
 				return err
 			}
 			w := len(strconv.Itoa(t))*2 + 1
+			if wp > 0 {
+				partial := strings.Repeat(" ", wp)
+				if n := partials[f]; n > 0 {
+					partial = pc.Sprint(fmt.Sprintf("%*s", wp, fmt.Sprintf("%d partial", n)))
+				}
+				trimed = fmt.Sprintf("%s %s", partial, trimed)
+			}
 			cmd.Printf("%s [%s] %s\n", c.Sprint(fmt.Sprintf("%5s%%", fmt.Sprintf("%.1f", floor1(cover)))), fmt.Sprintf(fmt.Sprintf("%%%ds", w), fmt.Sprintf("%d/%d", f.Covered, f.Total)), trimed)
 		}
 
+		printGeneratedFiles(cmd, r, prefix)
+
 		return nil
 	},
 }
 
+// partialLines returns the number of the partially covered lines of each file under the prefix, and the width to print them.
+// The width is 0 if no lines are partially covered.
+func partialLines(r *report.Report, prefix string) (map[*coverage.FileCoverage]int, int) {
+	partials := map[*coverage.FileCoverage]int{}
+	w := 0
+	for _, f := range r.Coverage.Files {
+		if !strings.HasPrefix(filepath.Clean(f.EffectivePath()), prefix) {
+			continue
+		}
+		n := f.ToLineCoverages().Partial()
+		if n == 0 {
+			continue
+		}
+		partials[f] = n
+		w = max(w, len(fmt.Sprintf("%d partial", n)))
+	}
+	return partials, w
+}
+
+// printGeneratedFiles prints the files excluded as generated sources with the reasons.
+func printGeneratedFiles(cmd *cobra.Command, r *report.Report, prefix string) {
+	generated := r.GeneratedFiles()
+	if len(generated) == 0 {
+		return
+	}
+	sort.Slice(generated, func(i, j int) bool {
+		return generated[i].File < generated[j].File
+	})
+	excluded := color.New(color.FgHiBlack)
+	excluded.EnableColor()
+	for _, f := range generated {
+		p := filepath.Clean(f.File)
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		trimed := strings.TrimPrefix(strings.TrimPrefix(p, prefix), "/")
+		cmd.Printf("%s %s (%s)\n", excluded.Sprint("excluded"), trimed, f.Reason)
+	}
+}
+
+// printFunctions prints the functions of each file with their call counts.
+func printFunctions(cmd *cobra.Command, c *config.Config, r *report.Report, prefix string) error {
+	covered, err := detectTermColor(c.CoverageColor(100.0))
+	if err != nil {
+		return err
+	}
+	uncovered, err := detectTermColor(c.CoverageColor(0.0))
+	if err != nil {
+		return err
+	}
+	w := 1
+	for _, f := range r.Coverage.Files {
+		for _, fn := range f.Functions {
+			w = max(w, len(strconv.FormatUint(uint64(fn.Count), 10)))
+		}
+	}
+	for _, f := range r.Coverage.Files {
+		p := filepath.Clean(f.EffectivePath())
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		trimed := strings.TrimPrefix(strings.TrimPrefix(p, prefix), "/")
+		for _, fn := range f.Functions {
+			cl := covered
+			if fn.Count == 0 {
+				cl = uncovered
+			}
+			cmd.Printf("%s %s:%d %s\n", cl.Sprint(fmt.Sprintf(fmt.Sprintf("%%%dd", w), uint64(fn.Count))), trimed, fn.StartLine, fn.Name)
+		}
+	}
+	return nil
+}
+
 func detectTermColor(cl string) (*color.Color, error) {
 	termGreen, err := colorful.Hex("#4e9a06")
 	if err != nil {
@@ -158,4 +250,5 @@ func init() {
 	rootCmd.AddCommand(lsFilesCmd)
 	lsFilesCmd.Flags().StringVarP(&configPath, "config", "", "", "config file path")
 	lsFilesCmd.Flags().StringVarP(&reportPath, "report", "r", "", "coverage report file path")
+	lsFilesCmd.Flags().BoolVarP(&functions, "functions", "", false, "list functions with the number of calls")
 }
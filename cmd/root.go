@@ -31,6 +31,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/k1LoW/octocov/badge"
@@ -50,6 +51,7 @@ var (
 	configPath  string
 	reportPath  string
 	createTable bool
+	functions   bool
 )
 
 var rootCmd = &cobra.Command{
@@ -161,7 +163,7 @@ var rootCmd = &cobra.Command{
 			return nil
 		}
 
-		r, err := report.New(c.Repository, report.Locale(c.Locale))
+		r, err := report.New(c.Repository, reportOptions(c, report.Locale(c.Locale))...)
 		if err != nil {
 			return err
 		}
@@ -174,6 +176,36 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
+		// Get previous report for comparing reports
+		var rPrev *report.Report
+		if err := c.DiffConfigReady(); err == nil {
+			rPrev, err = fetchPreviousReport(ctx, c, r)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(c.Coverage.CarryForward) > 0 {
+			if rPrev == nil {
+				cmd.PrintErrln("Skip carrying forward code coverage: previous report not found")
+			} else if err := r.CarryForward(rPrev, c.Coverage.CarryForward); err != nil {
+				cmd.PrintErrf("Skip carrying forward code coverage: %v\n", err)
+			}
+		}
+
+		// The changed files are fetched at most once and shared by patch coverage and the report contents.
+		changedFiles := sync.OnceValues(func() ([]*gh.PullRequestFile, error) {
+			return fetchChangedFiles(ctx, c)
+		})
+
+		if r.IsMeasuredCoverage() && inPullRequest(ctx, c) {
+			if files, err := changedFiles(); err != nil {
+				cmd.PrintErrf("Skip measuring patch coverage: %v\n", err)
+			} else {
+				r.MeasurePatchCoverage(files)
+			}
+		}
+
 		if err := c.CodeToTestRatioConfigReady(); err != nil {
 			cmd.PrintErrf("Skip measuring code to test ratio: %v\n", err)
 		} else {
@@ -245,6 +277,42 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
+		// Generate component coverage badges
+		for _, cc := range c.Coverage.Components {
+			if cc.Badge.Path == "" {
+				continue
+			}
+			if err := func() error {
+				if !r.IsMeasuredComponentCoverage(cc.Name) {
+					cmd.PrintErrf("Skip generating badge: coverage of component %s is not measured\n", cc.Name)
+					return nil
+				}
+				cp := r.ComponentCoveragePercent(cc.Name)
+				cmd.PrintErrf("Generate coverage report badge of component %s...\n", cc.Name)
+				out, err := badgeFile(cc.Badge.Path)
+				if err != nil {
+					return err
+				}
+				bp, err := filepath.Abs(filepath.Clean(cc.Badge.Path))
+				if err != nil {
+					return err
+				}
+				addPaths = append(addPaths, bp)
+
+				b := badge.New(fmt.Sprintf("coverage (%s)", cc.Name), fmt.Sprintf("%.1f%%", floor1(cp)))
+				b.MessageColor = c.CoverageColor(cp)
+				if err := b.AddIcon(internal.Icon); err != nil {
+					return err
+				}
+				if err := b.Render(out); err != nil {
+					return err
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+
 		// Generate code-to-test-ratio report badge
 		if err := c.CodeToTestRatioBadgeConfigReady(); err == nil {
 			if err := func() error {
@@ -313,75 +381,6 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
-		// Get previous report for comparing reports
-		var rPrev *report.Report
-		if err := c.DiffConfigReady(); err == nil {
-			log.Println("Get previous report for comparing reports")
-			repo, err := gh.Parse(c.Repository)
-			if err != nil {
-				return err
-			}
-			path := fmt.Sprintf("%s/%s/report.json", repo.Owner, repo.Reponame())
-
-			// Collect filesystem files once for normalizing all loaded reports
-			var gitRoot string
-			var fsFiles []string
-			if wd, err := os.Getwd(); err == nil {
-				if gr, err := internal.GitRoot(wd); err == nil {
-					if fs, err := internal.CollectFiles(gr); err == nil {
-						gitRoot = gr
-						fsFiles = fs
-					}
-				}
-			}
-
-			for _, s := range c.Diff.Datastores {
-				log.Printf("Get previous report from %s", s)
-				d, err := datastore.New(ctx, s, datastore.Root(c.Root()), datastore.Report(r))
-				if err != nil {
-					return err
-				}
-				fsys, err := d.FS()
-				if err != nil {
-					return err
-				}
-				f, err := fsys.Open(path)
-				if err != nil {
-					log.Printf("%s: %v", s, err)
-					continue
-				}
-				defer f.Close()
-				b, err := io.ReadAll(f)
-				if err != nil {
-					log.Printf("%s: %v", s, err)
-					continue
-				}
-				rt := &report.Report{}
-				if err := json.Unmarshal(b, rt); err != nil {
-					log.Printf("%s: %v %s", s, err, string(b))
-					continue
-				}
-				if rt.Coverage != nil {
-					rt.Coverage.NormalizePaths(gitRoot, fsFiles)
-				}
-				// Select latest report
-				if rPrev == nil || rPrev.Timestamp.UnixNano() < rt.Timestamp.UnixNano() {
-					rPrev = rt
-				}
-			}
-			if c.Diff.Path != "" {
-				rt, err := report.New(c.Repository, report.Locale(c.Locale))
-				if err != nil {
-					return err
-				}
-				if err := rt.MeasureCoverage([]string{c.Diff.Path}, c.Coverage.Exclude); err == nil {
-					if rPrev == nil || rPrev.Timestamp.UnixNano() < rt.Timestamp.UnixNano() {
-						rPrev = rt
-					}
-				}
-			}
-		}
-
 		// Comment report to pull request
 		if err := c.CommentConfigReady(); err != nil {
 			cmd.PrintErrf("Skip commenting report to pull request: %v\n", err)
@@ -394,10 +393,11 @@ var rootCmd = &cobra.Command{
 				if err := c.DiffConfigReady(); err != nil {
 					cmd.PrintErrf("Skip comparing reports: %v\n", err)
 				}
-				content, err := createReportContent(ctx, c, r, rPrev, c.Comment.Message, c.Comment.HideFooterLink)
+				files, err := changedFiles()
 				if err != nil {
 					return err
 				}
+				content := createReportContent(c, r, rPrev, files, c.Comment.Message, c.Comment.HideFooterLink, indirectChangesMax(c.Comment), newlyUncoveredLinesMax(c.Comment))
 				if err := commentReport(ctx, c, content, r.Key()); err != nil {
 					return err
 				}
@@ -419,10 +419,11 @@ var rootCmd = &cobra.Command{
 				if err := c.DiffConfigReady(); err != nil {
 					cmd.PrintErrf("Skip comparing reports: %v\n", err)
 				}
-				content, err := createReportContent(ctx, c, r, rPrev, c.Summary.Message, c.Summary.HideFooterLink)
+				files, err := changedFiles()
 				if err != nil {
 					return err
 				}
+				content := createReportContent(c, r, rPrev, files, c.Summary.Message, c.Summary.HideFooterLink, 0, 0)
 				if err := addReportContentToSummary(content); err != nil {
 					return err
 				}
@@ -444,10 +445,11 @@ var rootCmd = &cobra.Command{
 				if err := c.DiffConfigReady(); err != nil {
 					cmd.PrintErrf("Skip comparing reports: %v\n", err)
 				}
-				content, err := createReportContent(ctx, c, r, rPrev, c.Body.Message, c.Body.HideFooterLink)
+				files, err := changedFiles()
 				if err != nil {
 					return err
 				}
+				content := createReportContent(c, r, rPrev, files, c.Body.Message, c.Body.HideFooterLink, 0, 0)
 				if err := replaceInsertReportToBody(ctx, c, content, r.Key()); err != nil {
 					return err
 				}
@@ -472,6 +474,17 @@ var rootCmd = &cobra.Command{
 				}
 				addPaths = append(addPaths, rp)
 			}
+			if c.Report.HTML != "" && r.IsMeasuredCoverage() {
+				hp, err := filepath.Abs(filepath.Clean(c.Report.HTML))
+				if err != nil {
+					return err
+				}
+				paths, err := generateHTML(c, r, hp)
+				if err != nil {
+					return err
+				}
+				addPaths = append(addPaths, paths...)
+			}
 			if err := reportToDatastores(ctx, c, c.Report.Datastores, r); err != nil {
 				return err
 			}
@@ -518,7 +531,7 @@ func printMetrics(cmd *cobra.Command) error {
 		c.CodeToTestRatio = nil
 		c.TestExecutionTime = nil
 	}
-	r, err := report.New(c.Repository, report.Locale(c.Locale))
+	r, err := report.New(c.Repository, reportOptions(c, report.Locale(c.Locale))...)
 	if err != nil {
 		return err
 	}
@@ -605,6 +618,76 @@ func reportToDatastores(ctx context.Context, c *config.Config, datastores []stri
 	return nil
 }
 
+// fetchPreviousReport fetches the latest of the previous reports in the datastores and the path of `diff:`.
+func fetchPreviousReport(ctx context.Context, c *config.Config, r *report.Report) (*report.Report, error) {
+	var rPrev *report.Report
+	log.Println("Get previous report for comparing reports")
+	repo, err := gh.Parse(c.Repository)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/%s/report.json", repo.Owner, repo.Reponame())
+
+	// Collect filesystem files once for normalizing all loaded reports
+	var gitRoot string
+	var fsFiles []string
+	if wd, err := os.Getwd(); err == nil {
+		if gr, err := internal.GitRoot(wd); err == nil {
+			if fs, err := internal.CollectFiles(gr); err == nil {
+				gitRoot = gr
+				fsFiles = fs
+			}
+		}
+	}
+
+	for _, s := range c.Diff.Datastores {
+		log.Printf("Get previous report from %s", s)
+		d, err := datastore.New(ctx, s, datastore.Root(c.Root()), datastore.Report(r))
+		if err != nil {
+			return nil, err
+		}
+		fsys, err := d.FS()
+		if err != nil {
+			return nil, err
+		}
+		f, err := fsys.Open(path)
+		if err != nil {
+			log.Printf("%s: %v", s, err)
+			continue
+		}
+		defer f.Close()
+		b, err := io.ReadAll(f)
+		if err != nil {
+			log.Printf("%s: %v", s, err)
+			continue
+		}
+		rt := &report.Report{}
+		if err := json.Unmarshal(b, rt); err != nil {
+			log.Printf("%s: %v %s", s, err, string(b))
+			continue
+		}
+		if rt.Coverage != nil {
+			rt.Coverage.NormalizePaths(gitRoot, fsFiles)
+		}
+		// Select latest report
+		if rPrev == nil || rPrev.Timestamp.UnixNano() < rt.Timestamp.UnixNano() {
+			rPrev = rt
+		}
+	}
+	if c.Diff.Path != "" {
+		rt, err := report.New(c.Repository, report.Locale(c.Locale))
+		if err != nil {
+			return nil, err
+		}
+		if err := rt.MeasureCoverage([]string{c.Diff.Path}, c.Coverage.Exclude); err == nil {
+			if rPrev == nil || rPrev.Timestamp.UnixNano() < rt.Timestamp.UnixNano() {
+				rPrev = rt
+			}
+		}
+	}
+	return rPrev, nil
+}
+
 func badgeFile(path string) (*os.File, error) {
 	err := os.MkdirAll(filepath.Dir(path), 0755) // #nosec
 	if err != nil {
@@ -617,6 +700,31 @@ func badgeFile(path string) (*os.File, error) {
 	return out, nil
 }
 
+// reportOptions returns the options of the report for measuring code coverage as configured, following opts.
+func reportOptions(c *config.Config, opts ...report.Option) []report.Option {
+	return append(opts,
+		report.Formats(c.Coverage.Formats),
+		report.Labels(c.Coverage.Labels),
+		report.AggregateLines(c.Coverage.AggregateLines),
+		report.Concurrency(c.Coverage.Concurrency),
+		report.Components(componentPatterns(c)),
+		report.ExcludeGenerated(c.Coverage.ExcludeGenerated),
+		report.Include(c.Coverage.Include),
+	)
+}
+
+// componentPatterns returns the file patterns of the coverage components by name.
+func componentPatterns(c *config.Config) map[string][]string {
+	if len(c.Coverage.Components) == 0 {
+		return nil
+	}
+	m := map[string][]string{}
+	for _, cc := range c.Coverage.Components {
+		m[cc.Name] = cc.Paths
+	}
+	return m
+}
+
 func Execute() {
 	rootCmd.SetOut(os.Stdout)
 	rootCmd.SetErr(os.Stderr)
@@ -22,6 +22,7 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -32,6 +33,19 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	bySuite      bool
+	uncovered    bool
+	contextLines int
+	viewJSON     bool
+)
+
+// uncoveredFile is the uncovered ranges of a file printed by `view --json`.
+type uncoveredFile struct {
+	File      string                     `json:"file"`
+	Uncovered []*coverage.UncoveredRange `json:"uncovered"`
+}
+
 // viewCmd represents the view command.
 var viewCmd = &cobra.Command{
 	Use:     "view [FILE ...]",
@@ -53,13 +67,20 @@ var viewCmd = &cobra.Command{
 		if c.Coverage == nil {
 			return errors.New("coverage: is not set")
 		}
-		r, err := report.New(c.Repository)
+		r, err := report.New(c.Repository, reportOptions(c)...)
 		if err != nil {
 			return err
 		}
 		if err := r.MeasureCoverage(c.Coverage.Paths, c.Coverage.Exclude); err != nil {
 			return err
 		}
+		if viewJSON {
+			return printUncoveredJSON(cmd, r, args)
+		}
+		opts := []coverage.PrinterOption{coverage.BySuite(bySuite)}
+		if uncovered {
+			opts = append(opts, coverage.Uncovered(contextLines))
+		}
 		for _, f := range args {
 			err := func() error {
 				if _, err := os.Stat(f); err != nil {
@@ -69,13 +90,7 @@ var viewCmd = &cobra.Command{
 				if err != nil {
 					return err
 				}
-				fc, err := r.Coverage.Files.FuzzyFindByFile(f)
-				if err != nil {
-					fc = &coverage.FileCoverage{
-						File: f,
-					}
-				}
-				if err := coverage.NewPrinter(fc).Print(fp, os.Stdout); err != nil {
+				if err := coverage.NewPrinter(findFileCoverage(r, f), opts...).Print(fp, os.Stdout); err != nil {
 					_ = fp.Close() //nostyle:handlerrors
 					return err
 				}
@@ -92,8 +107,44 @@ var viewCmd = &cobra.Command{
 	},
 }
 
+// printUncoveredJSON prints the uncovered ranges of the files as JSON.
+func printUncoveredJSON(cmd *cobra.Command, r *report.Report, files []string) error {
+	ufs := []*uncoveredFile{}
+	for _, f := range files {
+		if _, err := os.Stat(f); err != nil {
+			return err
+		}
+		ranges := findFileCoverage(r, f).UncoveredRanges()
+		if ranges == nil {
+			ranges = []*coverage.UncoveredRange{}
+		}
+		ufs = append(ufs, &uncoveredFile{File: f, Uncovered: ranges})
+	}
+	b, err := json.MarshalIndent(ufs, "", "  ")
+	if err != nil {
+		return err
+	}
+	cmd.Println(string(b))
+	return nil
+}
+
+// findFileCoverage returns the coverage of the file, or the empty coverage if the file is not in the report.
+func findFileCoverage(r *report.Report, f string) *coverage.FileCoverage {
+	fc, err := r.Coverage.Files.FuzzyFindByFile(f)
+	if err != nil {
+		return &coverage.FileCoverage{
+			File: f,
+		}
+	}
+	return fc
+}
+
 func init() {
 	rootCmd.AddCommand(viewCmd)
 	viewCmd.Flags().StringVarP(&configPath, "config", "", "", "config file path")
 	viewCmd.Flags().StringVarP(&reportPath, "report", "r", "", "coverage report file path")
+	viewCmd.Flags().BoolVarP(&bySuite, "by-suite", "", false, "show the test suites executing each line")
+	viewCmd.Flags().BoolVarP(&uncovered, "uncovered", "", false, "show only the uncovered lines with context lines")
+	viewCmd.Flags().IntVarP(&contextLines, "context", "C", 3, "number of context lines around the uncovered lines")
+	viewCmd.Flags().BoolVarP(&viewJSON, "json", "", false, "output the uncovered ranges as JSON")
 }
@@ -26,11 +26,23 @@ func (c *Config) Build() {
 		c.Coverage.Paths = append(c.Coverage.Paths, filepath.Dir(c.path))
 	} else {
 		var paths []string
+		formats := map[string]string{}
+		labels := map[string]string{}
 		for _, p := range c.Coverage.Paths {
-			p = filepath.FromSlash(p)
-			paths = append(paths, filepath.Join(filepath.Dir(c.path), p))
+			f, ok := c.Coverage.Formats[p]
+			l, lok := c.Coverage.Labels[p]
+			p = filepath.Join(filepath.Dir(c.path), filepath.FromSlash(p))
+			paths = append(paths, p)
+			if ok {
+				formats[p] = f
+			}
+			if lok {
+				labels[p] = l
+			}
 		}
 		c.Coverage.Paths = paths
+		c.Coverage.Formats = formats
+		c.Coverage.Labels = labels
 	}
 
 	// TestExecutionTime
@@ -59,6 +71,12 @@ func (c *Config) Build() {
 	// Push
 
 	// Comment
+	if c.Comment != nil && c.Comment.IndirectChangesMax == 0 {
+		c.Comment.IndirectChangesMax = defaultIndirectChangesMax
+	}
+	if c.Comment != nil && c.Comment.NewlyUncoveredLinesMax == 0 {
+		c.Comment.NewlyUncoveredLinesMax = defaultNewlyUncoveredLinesMax
+	}
 
 	// Diff
 
@@ -25,6 +25,8 @@ import (
 const defaultBadgesDatastore = "local://reports"
 const defaultReportsDatastore = "local://reports"
 const defaultTimeout = "30sec"
+const defaultIndirectChangesMax = 10
+const defaultNewlyUncoveredLinesMax = 10
 const largeEnoughTime = float64(99 * time.Hour)
 
 const (
@@ -61,18 +63,55 @@ type Config struct {
 }
 
 type Coverage struct {
-	Path       string        `yaml:"path,omitempty"`
-	Paths      []string      `yaml:"paths,omitempty"`
-	Exclude    []string      `yaml:"exclude,omitempty"`
-	Badge      CoverageBadge `yaml:"badge,omitempty"`
+	Path       string             `yaml:"path,omitempty"`
+	Paths      []string           `yaml:"paths,omitempty"`
+	Exclude    []string           `yaml:"exclude,omitempty"`
+	Include    []string           `yaml:"include,omitempty"`
+	Badge      CoverageBadge      `yaml:"badge,omitempty"`
+	Acceptable string             `yaml:"acceptable,omitempty"`
+	Branch     *CoverageBranch    `yaml:"branch,omitempty"`
+	Functions  *CoverageFunctions `yaml:"functions,omitempty"`
+	Patch      *CoveragePatch     `yaml:"patch,omitempty"`
+	If         string             `yaml:"if,omitempty"`
+	// AggregateLines keeps only the per-line counts of the coverage reports to save memory.
+	AggregateLines bool `yaml:"aggregateLines,omitempty"`
+	// Concurrency is the number of coverage reports parsed at the same time.
+	Concurrency int `yaml:"concurrency,omitempty"`
+	// ExcludeGenerated excludes the files detected as generated sources.
+	ExcludeGenerated bool `yaml:"excludeGenerated,omitempty"`
+	// CarryForward are the groups of files whose coverage is carried forward from the previous report when they have no fresh coverage.
+	CarryForward []string `yaml:"carryForward,omitempty"`
+	// Formats maps paths in Paths to the formats of the coverage reports (`coverage.paths: [{path: ..., format: ...}]`).
+	Formats map[string]string `yaml:"-"`
+	// Labels maps paths in Paths to the labels of the test suites of the coverage reports (`coverage.paths: [{path: ..., label: ...}]`).
+	Labels map[string]string `yaml:"-"`
+	// Components are the named groups of files measured separately (`coverage.components:`), sorted by name.
+	Components []*CoverageComponent `yaml:"-"`
+}
+
+type CoverageComponent struct {
+	Name       string        `yaml:"-"`
+	Paths      []string      `yaml:"paths"`
 	Acceptable string        `yaml:"acceptable,omitempty"`
-	If         string        `yaml:"if,omitempty"`
+	Badge      CoverageBadge `yaml:"badge,omitempty"`
 }
 
 type CoverageBadge struct {
 	Path string `yaml:"path,omitempty"`
 }
 
+type CoverageBranch struct {
+	Acceptable string `yaml:"acceptable,omitempty"`
+}
+
+type CoverageFunctions struct {
+	Acceptable string `yaml:"acceptable,omitempty"`
+}
+
+type CoveragePatch struct {
+	Acceptable string `yaml:"acceptable,omitempty"`
+}
+
 type CodeToTestRatio struct {
 	Code       []string             `yaml:"code"`
 	Test       []string             `yaml:"test"`
@@ -119,11 +158,15 @@ type Push struct {
 }
 
 type Comment struct {
-	HideFooterLink bool   `yaml:"hideFooterLink"`
-	DeletePrevious bool   `yaml:"deletePrevious"`
-	UpdatePrevious bool   `yaml:"updatePrevious"`
-	Message        string `yaml:"message,omitempty"`
-	If             string `yaml:"if,omitempty"`
+	HideFooterLink          bool   `yaml:"hideFooterLink"`
+	DeletePrevious          bool   `yaml:"deletePrevious"`
+	UpdatePrevious          bool   `yaml:"updatePrevious"`
+	HideIndirectChanges     bool   `yaml:"hideIndirectChanges,omitempty"`
+	IndirectChangesMax      int    `yaml:"indirectChangesMax,omitempty"`
+	HideNewlyUncoveredLines bool   `yaml:"hideNewlyUncoveredLines,omitempty"`
+	NewlyUncoveredLinesMax  int    `yaml:"newlyUncoveredLinesMax,omitempty"`
+	Message                 string `yaml:"message,omitempty"`
+	If                      string `yaml:"if,omitempty"`
 }
 
 type Summary struct {
@@ -202,6 +245,14 @@ func (c *Config) Loaded() bool {
 
 type Reporter interface {
 	CoveragePercent() float64
+	BranchCoveragePercent() float64
+	IsMeasuredBranchCoverage() bool
+	FunctionCoveragePercent() float64
+	IsMeasuredFunctionCoverage() bool
+	PatchCoveragePercent() float64
+	IsMeasuredPatchCoverage() bool
+	ComponentCoveragePercent(name string) float64
+	IsMeasuredComponentCoverage(name string) bool
 	CodeToTestRatioRatio() float64
 	TestExecutionTimeNano() float64
 	IsMeasuredTestExecutionTime() bool
@@ -216,6 +267,41 @@ func (c *Config) Acceptable(r, rPrev Reporter) error {
 		if err := coverageAcceptable(curr, prev, c.Coverage.Acceptable); err != nil {
 			errs = errors.Join(errs, err)
 		}
+		// Branch coverage is only checked when the coverage report contains branch data.
+		if c.Coverage.Branch != nil && r.IsMeasuredBranchCoverage() {
+			prev := big.NewRat(int64(rPrev.BranchCoveragePercent()*10000), 10000)
+			curr := big.NewRat(int64(r.BranchCoveragePercent()*10000), 10000)
+			if err := branchCoverageAcceptable(curr, prev, c.Coverage.Branch.Acceptable); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+		// Function coverage is only checked when the coverage report contains function data.
+		if c.Coverage.Functions != nil && r.IsMeasuredFunctionCoverage() {
+			prev := big.NewRat(int64(rPrev.FunctionCoveragePercent()*10000), 10000)
+			curr := big.NewRat(int64(r.FunctionCoveragePercent()*10000), 10000)
+			if err := functionCoverageAcceptable(curr, prev, c.Coverage.Functions.Acceptable); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+		// Patch coverage is only checked when the pull request changes coverable lines.
+		// The previous report has no patch coverage to compare with, so only `current` is available.
+		if c.Coverage.Patch != nil && r.IsMeasuredPatchCoverage() {
+			curr := big.NewRat(int64(r.PatchCoveragePercent()*10000), 10000)
+			if err := patchCoverageAcceptable(curr, c.Coverage.Patch.Acceptable); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+		// Component coverage is only checked when the component contains files.
+		for _, cc := range c.Coverage.Components {
+			if !r.IsMeasuredComponentCoverage(cc.Name) {
+				continue
+			}
+			prev := big.NewRat(int64(rPrev.ComponentCoveragePercent(cc.Name)*10000), 10000)
+			curr := big.NewRat(int64(r.ComponentCoveragePercent(cc.Name)*10000), 10000)
+			if err := componentCoverageAcceptable(cc.Name, curr, prev, cc.Acceptable); err != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
 	}
 
 	if err := c.CodeToTestRatioConfigReady(); err == nil {
@@ -258,6 +344,28 @@ var (
 )
 
 func coverageAcceptable(current, prev *big.Rat, cond string) error {
+	return percentAcceptable(current, prev, cond, "code coverage", "coverage.acceptable:")
+}
+
+func branchCoverageAcceptable(current, prev *big.Rat, cond string) error {
+	return percentAcceptable(current, prev, cond, "branch coverage", "coverage.branch.acceptable:")
+}
+
+func functionCoverageAcceptable(current, prev *big.Rat, cond string) error {
+	return percentAcceptable(current, prev, cond, "function coverage", "coverage.functions.acceptable:")
+}
+
+func patchCoverageAcceptable(current *big.Rat, cond string) error {
+	return percentAcceptable(current, nil, cond, "patch coverage", "coverage.patch.acceptable:")
+}
+
+func componentCoverageAcceptable(name string, current, prev *big.Rat, cond string) error {
+	return percentAcceptable(current, prev, cond, fmt.Sprintf("coverage of component `%s`", name), fmt.Sprintf("coverage.components.%s.acceptable:", name))
+}
+
+// percentAcceptable evaluates the condition on a percentage metric. The metric and the config section name the metric in the error.
+// `prev` and `diff` are not available in the condition if prev is nil.
+func percentAcceptable(current, prev *big.Rat, cond, metric, section string) error {
 	if cond == "" {
 		return nil
 	}
@@ -271,14 +379,16 @@ func coverageAcceptable(current, prev *big.Rat, cond string) error {
 		cond = fmt.Sprintf("current %s", cond)
 	}
 
-	diff := new(big.Rat).Sub(current, prev)
-	diffF, _ := diff.Float64()
 	currentF, _ := current.Float64()
-	prevF, _ := prev.Float64()
 	variables := map[string]any{
 		"current": currentF,
-		"prev":    prevF,
-		"diff":    diffF,
+	}
+	if prev != nil {
+		diff := new(big.Rat).Sub(current, prev)
+		diffF, _ := diff.Float64()
+		prevF, _ := prev.Float64()
+		variables["prev"] = prevF
+		variables["diff"] = diffF
 	}
 	ok, err := expr.Eval(fmt.Sprintf("(%s) == true", cond), variables)
 	if err != nil {
@@ -290,7 +400,7 @@ func coverageAcceptable(current, prev *big.Rat, cond string) error {
 		return fmt.Errorf("invalid condition `%s`", cond)
 	}
 	if !tf {
-		return fmt.Errorf("code coverage is %.1f%%. the condition in the `coverage.acceptable:` section is not met (`%s`)", floor1(currentF), org)
+		return fmt.Errorf("%s is %.1f%%. the condition in the `%s` section is not met (`%s`)", metric, floor1(currentF), section, org)
 	}
 	return nil
 }
@@ -58,6 +58,8 @@ func TestLoadComment(t *testing.T) {
 		{"comment_enabled_octocov.yml", &Comment{}},
 		{"comment_enabled_octocov2.yml", &Comment{If: "is_pull_request"}},
 		{"comment_disabled_octocov.yml", nil},
+		{"comment_indirect_changes_octocov.yml", &Comment{HideIndirectChanges: true, IndirectChangesMax: 5}},
+		{"comment_newly_uncovered_lines_octocov.yml", &Comment{HideNewlyUncoveredLines: true, NewlyUncoveredLinesMax: 3}},
 	}
 	for _, tt := range tests {
 		c := New()
@@ -158,6 +160,75 @@ func TestCoveragePaths(t *testing.T) {
 	}
 }
 
+func TestLoadCoveragePathsWithFormat(t *testing.T) {
+	c := New()
+	p := filepath.Join(testdataDir(t), "coverage_paths_format_octocov.yml")
+	if err := c.Load(p); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"coverage.out", "build/jacoco.xml"}; !cmp.Equal(c.Coverage.Paths, want) {
+		t.Errorf("got %v\nwant %v", c.Coverage.Paths, want)
+	}
+	if want := "60%"; c.Coverage.Acceptable != want {
+		t.Errorf("got %v\nwant %v", c.Coverage.Acceptable, want)
+	}
+	c.Build()
+	want := map[string]string{
+		filepath.Join(testdataDir(t), "build", "jacoco.xml"): "jacoco",
+	}
+	if diff := cmp.Diff(c.Coverage.Formats, want, nil); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestLoadCoveragePathsWithLabel(t *testing.T) {
+	c := New()
+	p := filepath.Join(testdataDir(t), "coverage_paths_label_octocov.yml")
+	if err := c.Load(p); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"coverage/unit.out", "coverage/e2e.lcov", "coverage/other.out"}; !cmp.Equal(c.Coverage.Paths, want) {
+		t.Errorf("got %v\nwant %v", c.Coverage.Paths, want)
+	}
+	c.Build()
+	want := map[string]string{
+		filepath.Join(testdataDir(t), "coverage", "unit.out"): "unit",
+		filepath.Join(testdataDir(t), "coverage", "e2e.lcov"): "e2e",
+	}
+	if diff := cmp.Diff(c.Coverage.Labels, want, nil); diff != "" {
+		t.Error(diff)
+	}
+	if want := map[string]string{filepath.Join(testdataDir(t), "coverage", "e2e.lcov"): "lcov"}; !cmp.Equal(c.Coverage.Formats, want) {
+		t.Errorf("got %v\nwant %v", c.Coverage.Formats, want)
+	}
+}
+
+func TestLoadCoverageComponents(t *testing.T) {
+	c := New()
+	p := filepath.Join(testdataDir(t), "coverage_components_octocov.yml")
+	if err := c.Load(p); err != nil {
+		t.Fatal(err)
+	}
+	want := []*CoverageComponent{
+		{
+			Name:       "api",
+			Paths:      []string{"api/**", "!api/mock/**"},
+			Acceptable: "80%",
+			Badge:      CoverageBadge{Path: "docs/coverage-api.svg"},
+		},
+		{
+			Name:  "web",
+			Paths: []string{"web/**"},
+		},
+	}
+	if diff := cmp.Diff(c.Coverage.Components, want, nil); diff != "" {
+		t.Error(diff)
+	}
+	if want := []string{"coverage.out"}; !cmp.Equal(c.Coverage.Paths, want) {
+		t.Errorf("got %v\nwant %v", c.Coverage.Paths, want)
+	}
+}
+
 func TestCoverageAcceptable(t *testing.T) {
 	// Pre-calculate special big.Rat values
 	// For comparing 59.9999999999999 and 60
@@ -243,6 +314,145 @@ func TestCoverageAcceptable(t *testing.T) {
 	}
 }
 
+func TestBranchCoverageAcceptable(t *testing.T) {
+	tests := []struct {
+		cond    string
+		cov     float64
+		prev    float64
+		wantErr bool
+		errMsg  string
+	}{
+		{"", 50.0, 0, false, ""},
+		{"60%", 50.0, 0, true, "branch coverage is 50.0%. the condition in the `coverage.branch.acceptable:` section is not met (`60%`)"},
+		{"50%", 50.0, 0, false, ""},
+		{">= 49.9", 50.0, 0, false, ""},
+		{"> 50%", 50.0, 0, true, "branch coverage is 50.0%. the condition in the `coverage.branch.acceptable:` section is not met (`> 50%`)"},
+		{"current > prev", 50.0, 49.0, false, ""},
+		{"diff >= 0", 48.0, 49.0, true, "branch coverage is 48.0%. the condition in the `coverage.branch.acceptable:` section is not met (`diff >= 0`)"},
+		{"current >= 50% && diff >= 0%", 50.0, 49.0, false, ""},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", i), func(t *testing.T) {
+			covRat := big.NewRat(int64(tt.cov*10000), 10000)
+			prevRat := big.NewRat(int64(tt.prev*10000), 10000)
+			if err := branchCoverageAcceptable(covRat, prevRat, tt.cond); err != nil {
+				if !tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+				}
+				if tt.errMsg != "" && err.Error() != tt.errMsg {
+					t.Errorf("got %v\nwant %v", err.Error(), tt.errMsg)
+				}
+			} else {
+				if tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", nil, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestFunctionCoverageAcceptable(t *testing.T) {
+	tests := []struct {
+		cond    string
+		cov     float64
+		prev    float64
+		wantErr bool
+		errMsg  string
+	}{
+		{"", 50.0, 0, false, ""},
+		{"80%", 75.0, 0, true, "function coverage is 75.0%. the condition in the `coverage.functions.acceptable:` section is not met (`80%`)"},
+		{"75%", 75.0, 0, false, ""},
+		{"current >= 70% && diff >= 0", 75.0, 80.0, true, "function coverage is 75.0%. the condition in the `coverage.functions.acceptable:` section is not met (`current >= 70% && diff >= 0`)"},
+		{"current > prev", 75.0, 70.0, false, ""},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", i), func(t *testing.T) {
+			covRat := big.NewRat(int64(tt.cov*10000), 10000)
+			prevRat := big.NewRat(int64(tt.prev*10000), 10000)
+			if err := functionCoverageAcceptable(covRat, prevRat, tt.cond); err != nil {
+				if !tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+				}
+				if tt.errMsg != "" && err.Error() != tt.errMsg {
+					t.Errorf("got %v\nwant %v", err.Error(), tt.errMsg)
+				}
+			} else {
+				if tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", nil, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchCoverageAcceptable(t *testing.T) {
+	tests := []struct {
+		cond    string
+		cov     float64
+		prev    float64
+		wantErr bool
+		errMsg  string
+	}{
+		{"", 50.0, 0, false, ""},
+		{"80%", 75.0, 0, true, "patch coverage is 75.0%. the condition in the `coverage.patch.acceptable:` section is not met (`80%`)"},
+		{"75%", 75.0, 0, false, ""},
+		{"current > 70", 75.0, 0, false, ""},
+		{"current >= prev", 75.0, 80.0, true, ""},
+		{"diff >= 0", 85.0, 80.0, true, ""},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", i), func(t *testing.T) {
+			covRat := big.NewRat(int64(tt.cov*10000), 10000)
+			if err := patchCoverageAcceptable(covRat, tt.cond); err != nil {
+				if !tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+				}
+				if tt.errMsg != "" && err.Error() != tt.errMsg {
+					t.Errorf("got %v\nwant %v", err.Error(), tt.errMsg)
+				}
+			} else {
+				if tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", nil, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestComponentCoverageAcceptable(t *testing.T) {
+	tests := []struct {
+		cond    string
+		cov     float64
+		prev    float64
+		wantErr bool
+		errMsg  string
+	}{
+		{"", 50.0, 0, false, ""},
+		{"60%", 50.0, 0, true, "coverage of component `api` is 50.0%. the condition in the `coverage.components.api.acceptable:` section is not met (`60%`)"},
+		{"50%", 50.0, 0, false, ""},
+		{"diff >= 0", 48.0, 49.0, true, "coverage of component `api` is 48.0%. the condition in the `coverage.components.api.acceptable:` section is not met (`diff >= 0`)"},
+		{"current > prev", 50.0, 49.0, false, ""},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", i), func(t *testing.T) {
+			covRat := big.NewRat(int64(tt.cov*10000), 10000)
+			prevRat := big.NewRat(int64(tt.prev*10000), 10000)
+			if err := componentCoverageAcceptable("api", covRat, prevRat, tt.cond); err != nil {
+				if !tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+				}
+				if tt.errMsg != "" && err.Error() != tt.errMsg {
+					t.Errorf("got %v\nwant %v", err.Error(), tt.errMsg)
+				}
+			} else {
+				if tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", nil, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
 func TestCodeToTestRatioAcceptable(t *testing.T) {
 	// Pre-calculate special big.Rat values
 	// Value of 1/3
@@ -293,8 +293,8 @@ func (c *Config) ReportConfigTargetReady() error {
 	if c.Report == nil {
 		return errors.New("report: is not set")
 	}
-	if c.Report.Path == "" && len(c.Report.Datastores) == 0 {
-		return errors.New("report.datastores: and report.path: are not set")
+	if c.Report.Path == "" && c.Report.HTML == "" && len(c.Report.Datastores) == 0 {
+		return errors.New("report.datastores:, report.path: and report.html: are not set")
 	}
 	return nil
 }
@@ -663,7 +663,7 @@ func TestReportConfigReady(t *testing.T) {
 				Report:     &Report{},
 				gh:         mockedGh(t),
 			},
-			"report.datastores: and report.path: are not set",
+			"report.datastores:, report.path: and report.html: are not set",
 		},
 		{
 			&Config{
@@ -677,6 +677,16 @@ func TestReportConfigReady(t *testing.T) {
 			},
 			"",
 		},
+		{
+			&Config{
+				Repository: "owner/repo",
+				Report: &Report{
+					HTML: "coverage-html",
+				},
+				gh: mockedGh(t),
+			},
+			"",
+		},
 		{
 			&Config{
 				Repository: "owner/repo",
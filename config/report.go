@@ -3,5 +3,6 @@ package config
 type Report struct {
 	If         string   `yaml:"if,omitempty"`
 	Path       string   `yaml:"path,omitempty"`
+	HTML       string   `yaml:"html,omitempty"`
 	Datastores []string `yaml:"datastores,omitempty"`
 }
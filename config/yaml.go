@@ -1,7 +1,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 
 	"github.com/goccy/go-yaml"
 	"github.com/k1LoW/duration"
@@ -98,6 +102,80 @@ func (c *Config) UnmarshalYAML(data []byte) error {
 	return nil
 }
 
+func (c *Coverage) UnmarshalYAML(data []byte) error {
+	type coverage Coverage
+	m := map[string]any{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	// coverage.paths: accepts both paths and {path: ..., format: ..., label: ...}
+	formats := map[string]string{}
+	labels := map[string]string{}
+	if v, ok := m["paths"].([]any); ok {
+		var paths []any
+		for _, p := range v {
+			pm, ok := p.(map[string]any)
+			if !ok {
+				paths = append(paths, p)
+				continue
+			}
+			path, _ := pm["path"].(string)
+			if path == "" {
+				return errors.New("coverage.paths: path is not set")
+			}
+			if f, _ := pm["format"].(string); f != "" {
+				formats[path] = f
+			}
+			if l, _ := pm["label"].(string); l != "" {
+				labels[path] = l
+			}
+			paths = append(paths, path)
+		}
+		m["paths"] = paths
+	}
+	// coverage.components: accepts both name: [paths...] and name: {paths: [...], acceptable: ..., badge: ...}
+	var components []*CoverageComponent
+	if v, ok := m["components"]; ok {
+		cm, ok := v.(map[string]any)
+		if !ok {
+			return errors.New("coverage.components: invalid format")
+		}
+		for _, name := range slices.Sorted(maps.Keys(cm)) {
+			cv := cm[name]
+			if _, ok := cv.([]any); ok {
+				cv = map[string]any{"paths": cv}
+			}
+			tmp, err := yaml.Marshal(cv)
+			if err != nil {
+				return err
+			}
+			cc := &CoverageComponent{}
+			if err := yaml.Unmarshal(tmp, cc); err != nil {
+				return fmt.Errorf("coverage.components.%s: %w", name, err)
+			}
+			if len(cc.Paths) == 0 {
+				return fmt.Errorf("coverage.components.%s: paths are not set", name)
+			}
+			cc.Name = name
+			components = append(components, cc)
+		}
+		delete(m, "components")
+	}
+	tmp, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	cc := coverage{}
+	if err := yaml.Unmarshal(tmp, &cc); err != nil {
+		return err
+	}
+	*c = Coverage(cc)
+	c.Formats = formats
+	c.Labels = labels
+	c.Components = components
+	return nil
+}
+
 func (c *Central) UnmarshalYAML(data []byte) error {
 	s := struct {
 		Root     string         `yaml:"root"`
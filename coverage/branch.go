@@ -0,0 +1,141 @@
+package coverage
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// BranchCoverage is the branch coverage of a line: the number of branches (outcomes of
+// conditions) on the line and how many of them were taken.
+type BranchCoverage struct {
+	Line    int `json:"line"`
+	Total   int `json:"total"`
+	Covered int `json:"covered"`
+	// Taken is whether each branch of the line was taken, in the order reported.
+	// It is only recorded by the formats identifying each branch (LCOV, gcov and llvm-cov).
+	Taken []bool `json:"taken,omitempty"`
+}
+
+type BranchCoverages []*BranchCoverage
+
+func (bc BranchCoverages) Total() int { //nostyle:recvtype
+	t := 0
+	for _, b := range bc {
+		t += b.Total
+	}
+	return t
+}
+
+func (bc BranchCoverages) Covered() int { //nostyle:recvtype
+	c := 0
+	for _, b := range bc {
+		c += b.Covered
+	}
+	return c
+}
+
+func (bc BranchCoverages) FindByLine(l int) (*BranchCoverage, error) { //nostyle:recvtype
+	for _, b := range bc {
+		if b.Line == l {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("branch coverage not found: %d", l)
+}
+
+// addBranches adds branches of a line reported by a parser, summing them up with the branches already recorded on the line.
+func (bc BranchCoverages) addBranches(line, total, covered int) BranchCoverages { //nostyle:recvtype
+	if total <= 0 {
+		return bc
+	}
+	if covered > total {
+		covered = total
+	}
+	if b, err := bc.FindByLine(line); err == nil {
+		b.Total += total
+		b.Covered += covered
+		return bc
+	}
+	return append(bc, &BranchCoverage{Line: line, Total: total, Covered: covered})
+}
+
+// addTaken adds branches of a line reported one by one by a parser, in the order they are reported.
+func (bc BranchCoverages) addTaken(line int, taken ...bool) BranchCoverages { //nostyle:recvtype
+	if len(taken) == 0 {
+		return bc
+	}
+	covered := 0
+	for _, t := range taken {
+		if t {
+			covered++
+		}
+	}
+	b, err := bc.FindByLine(line)
+	if err != nil {
+		b = &BranchCoverage{Line: line}
+		bc = append(bc, b)
+	}
+	if b.identified() {
+		b.Taken = append(b.Taken, taken...)
+	}
+	b.Total += len(taken)
+	b.Covered += covered
+	return bc
+}
+
+// merge merges the branches of the same file from another report.
+// If both reports identify each branch of a line, a branch taken in either report is taken.
+// Otherwise only the numbers of the branches are known, so the branches of a line are assumed
+// to be the same ones and the larger numbers win.
+func (bc BranchCoverages) merge(bc2 BranchCoverages) BranchCoverages { //nostyle:recvtype
+	for _, b2 := range bc2 {
+		b, err := bc.FindByLine(b2.Line)
+		if err != nil {
+			bc = append(bc, &BranchCoverage{Line: b2.Line, Total: b2.Total, Covered: b2.Covered, Taken: slices.Clone(b2.Taken)})
+			continue
+		}
+		if b.identified() && b2.identified() && b.Total == b2.Total {
+			b.Covered = 0
+			for i := range b.Taken {
+				b.Taken[i] = b.Taken[i] || b2.Taken[i]
+				if b.Taken[i] {
+					b.Covered++
+				}
+			}
+			continue
+		}
+		b.Total = max(b.Total, b2.Total)
+		b.Covered = min(max(b.Covered, b2.Covered), b.Total)
+		b.Taken = nil
+	}
+	bc.sort()
+	return bc
+}
+
+func (bc BranchCoverages) sort() { //nostyle:recvtype
+	sort.SliceStable(bc, func(i, j int) bool {
+		return bc[i].Line < bc[j].Line
+	})
+}
+
+// identified reports whether each branch of the line is identified by Taken.
+func (b *BranchCoverage) identified() bool {
+	return len(b.Taken) == b.Total
+}
+
+// setBranches sets the branches of the file and their totals.
+func (fc *FileCoverage) setBranches(bc BranchCoverages) {
+	bc.sort()
+	fc.Branches = bc
+	fc.BranchTotal = bc.Total()
+	fc.BranchCovered = bc.Covered()
+}
+
+// BranchPercent returns the branch coverage of the report. It returns 0 if no branches are recorded.
+func (c *Coverage) BranchPercent() float64 {
+	if c == nil || c.BranchTotal == 0 {
+		return 0.0
+	}
+	return float64(c.BranchCovered) / float64(c.BranchTotal) * 100
+}
@@ -0,0 +1,170 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBranches(t *testing.T) {
+	tests := []struct {
+		name        string
+		processor   Processor
+		path        string
+		wantTotal   int
+		wantCovered int
+	}{
+		{"lcov", NewLcov(), filepath.Join(testdataDir(t), "lcov_branch", "branch.lcov"), 6, 3},
+		{"cobertura", NewCobertura(), filepath.Join(testdataDir(t), "cobertura_branch", "coverage.xml"), 6, 3},
+		// Same as the report level BRANCH counter.
+		{"jacoco", NewJacoco(), filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), 4455, 3540},
+		{"sonar", NewSonar(), filepath.Join(testdataDir(t), "sonar", "coverage.xml"), 4, 1},
+		{"istanbul", NewIstanbul(), filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json"), 4, 2},
+		{"coverage.py", NewCoveragePy(), filepath.Join(testdataDir(t), "coveragepy", "coverage.json"), 4, 2},
+		{"llvm-cov", NewLLVMCov(), filepath.Join(testdataDir(t), "llvmcov", "coverage.json"), 4, 2},
+		{"opencover", NewOpenCover(), filepath.Join(testdataDir(t), "opencover", "coverage.opencover.xml"), 2, 1},
+		{"gcov", NewGcov(), filepath.Join(testdataDir(t), "gcov"), 2, 1},
+		{"gocover", NewGocover(), filepath.Join(testdataDir(t), "gocover", "coverage.out"), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := tt.processor.ParseReport(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.BranchTotal != tt.wantTotal {
+				t.Errorf("got %v\nwant %v", got.BranchTotal, tt.wantTotal)
+			}
+			if got.BranchCovered != tt.wantCovered {
+				t.Errorf("got %v\nwant %v", got.BranchCovered, tt.wantCovered)
+			}
+			total := 0
+			covered := 0
+			for _, f := range got.Files {
+				if f.BranchTotal != f.Branches.Total() {
+					t.Errorf("got %v\nwant %v", f.BranchTotal, f.Branches.Total())
+				}
+				total += f.BranchTotal
+				covered += f.BranchCovered
+			}
+			if total != got.BranchTotal {
+				t.Errorf("got %v\nwant %v", total, got.BranchTotal)
+			}
+			if covered != got.BranchCovered {
+				t.Errorf("got %v\nwant %v", covered, got.BranchCovered)
+			}
+		})
+	}
+}
+
+func TestLcovBranches(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "lcov_branch", "branch.lcov")
+	got, _, err := NewLcov().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := got.Files.FindByFile("src/calc.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BranchCoverages{
+		{Line: 2, Total: 2, Covered: 2, Taken: []bool{true, true}},
+		{Line: 5, Total: 2, Covered: 1, Taken: []bool{true, false}},
+		{Line: 7, Total: 2, Covered: 0, Taken: []bool{false, false}},
+	}
+	if diff := cmp.Diff(fc.Branches, want); diff != "" {
+		t.Error(diff)
+	}
+	if want := 50.0; got.BranchPercent() != want {
+		t.Errorf("got %v\nwant %v", got.BranchPercent(), want)
+	}
+}
+
+func TestMergeBranches(t *testing.T) {
+	c1 := &Coverage{
+		Type: TypeLOC,
+		Files: FileCoverages{
+			&FileCoverage{
+				File: "file_a.js",
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+				},
+				Branches: BranchCoverages{
+					{Line: 1, Total: 2, Covered: 1},
+				},
+			},
+		},
+	}
+	c2 := &Coverage{
+		Type: TypeLOC,
+		Files: FileCoverages{
+			&FileCoverage{
+				File: "file_a.js",
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+				},
+				Branches: BranchCoverages{
+					{Line: 1, Total: 2, Covered: 2},
+					{Line: 3, Total: 4, Covered: 0},
+				},
+			},
+			&FileCoverage{
+				File: "file_b.js",
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 0),
+				},
+			},
+		},
+	}
+	if err := c1.Merge(c2); err != nil {
+		t.Fatal(err)
+	}
+	if want := 6; c1.BranchTotal != want {
+		t.Errorf("got %v\nwant %v", c1.BranchTotal, want)
+	}
+	if want := 2; c1.BranchCovered != want {
+		t.Errorf("got %v\nwant %v", c1.BranchCovered, want)
+	}
+	fc, err := c1.Files.FindByFile("file_a.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BranchCoverages{
+		{Line: 1, Total: 2, Covered: 2},
+		{Line: 3, Total: 4, Covered: 0},
+	}
+	if diff := cmp.Diff(fc.Branches, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestMergeIdentifiedBranches(t *testing.T) {
+	unit := BranchCoverages{
+		{Line: 1, Total: 2, Covered: 1, Taken: []bool{true, false}},
+		{Line: 2, Total: 2, Covered: 1, Taken: []bool{true, false}},
+	}
+	integration := BranchCoverages{
+		{Line: 1, Total: 2, Covered: 1, Taken: []bool{false, true}},
+		// Branches without their identities fall back to the larger numbers.
+		{Line: 2, Total: 2, Covered: 1},
+		{Line: 3, Total: 2, Covered: 1, Taken: []bool{false, true}},
+	}
+	got := unit.merge(integration)
+	want := BranchCoverages{
+		{Line: 1, Total: 2, Covered: 2, Taken: []bool{true, true}},
+		{Line: 2, Total: 2, Covered: 1},
+		{Line: 3, Total: 2, Covered: 1, Taken: []bool{false, true}},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+	// The branches merged in do not share their outcomes with the other report.
+	got[2].Taken[0] = true
+	if integration[2].Taken[0] {
+		t.Error("got true\nwant false")
+	}
+}
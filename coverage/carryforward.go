@@ -0,0 +1,49 @@
+package coverage
+
+import "slices"
+
+// CarryForward adds the files of prev in the groups without any files in the coverage, marked as carried forward.
+// Each group is a doublestar pattern of the files measured together, such as the files of a test suite.
+// Files added by Include are not fresh coverage, so they are replaced by the files carried forward.
+// It returns the groups carried forward.
+func (c *Coverage) CarryForward(prev *Coverage, groups []string) ([]string, error) {
+	if c == nil || prev == nil {
+		return nil, nil
+	}
+	var carried []string
+	for _, g := range groups {
+		fresh, err := c.Files.Select([]string{g})
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(fresh, func(f *FileCoverage) bool { return !f.included }) {
+			continue
+		}
+		files, err := prev.Files.Select([]string{g})
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			continue
+		}
+		replaced := map[string]struct{}{}
+		for _, f := range files {
+			replaced[f.EffectivePath()] = struct{}{}
+		}
+		c.Files = slices.DeleteFunc(c.Files, func(f *FileCoverage) bool {
+			_, ok := replaced[f.EffectivePath()]
+			return f.included && ok
+		})
+		for _, f := range files {
+			cf := *f
+			cf.CarriedForward = true
+			cf.cache = nil
+			c.Files = append(c.Files, &cf)
+		}
+		carried = append(carried, g)
+	}
+	if len(carried) == 0 {
+		return nil, nil
+	}
+	return carried, c.reCalc()
+}
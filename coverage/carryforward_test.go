@@ -0,0 +1,108 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCarryForward(t *testing.T) {
+	prev := &Coverage{
+		Type: TypeLOC,
+		Files: FileCoverages{
+			&FileCoverage{File: "api/handler.go", Type: TypeLOC, Total: 10, Covered: 8},
+			&FileCoverage{File: "web/app.ts", Type: TypeLOC, Total: 4, Covered: 1},
+			&FileCoverage{File: "worker/job.py", Type: TypeLOC, Total: 6, Covered: 6, BranchTotal: 2, BranchCovered: 1},
+		},
+	}
+	c := &Coverage{
+		Type: TypeLOC,
+		Files: FileCoverages{
+			&FileCoverage{
+				File: "web/app.ts",
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+					newBlockCoverage(TypeLOC, 2, -1, 2, -1, -1, 1),
+				},
+			},
+		},
+	}
+	got, err := c.CarryForward(prev, []string{"api/**", "web/**", "worker/**", "docs/**"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"api/**", "worker/**"}, got); diff != "" {
+		t.Error(diff)
+	}
+	var files []string
+	for _, f := range c.Files {
+		files = append(files, f.File)
+	}
+	if diff := cmp.Diff([]string{"web/app.ts", "api/handler.go", "worker/job.py"}, files); diff != "" {
+		t.Error(diff)
+	}
+	if !c.Files[1].CarriedForward || c.Files[0].CarriedForward {
+		t.Error("only the files of the groups without fresh coverage should be carried forward")
+	}
+	if prev.Files[0].CarriedForward {
+		t.Error("the files of the previous coverage should not be modified")
+	}
+	if want := 18; c.Total != want {
+		t.Errorf("got %v\nwant %v", c.Total, want)
+	}
+	if want := 16; c.Covered != want {
+		t.Errorf("got %v\nwant %v", c.Covered, want)
+	}
+	if want := 2; c.BranchTotal != want {
+		t.Errorf("got %v\nwant %v", c.BranchTotal, want)
+	}
+
+	// The files carried forward keep their counts through reCalc.
+	if err := c.Exclude([]string{"worker/**"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := 12; c.Total != want {
+		t.Errorf("got %v\nwant %v", c.Total, want)
+	}
+}
+
+func TestCarryForwardIncluded(t *testing.T) {
+	prev := &Coverage{
+		Type: TypeLOC,
+		Files: FileCoverages{
+			&FileCoverage{File: "api/handler.go", Type: TypeLOC, Total: 10, Covered: 8},
+		},
+	}
+	c := &Coverage{
+		Type:  TypeLOC,
+		Files: FileCoverages{},
+	}
+	files := []string{"/repo/api/handler.go", "/repo/api/new.go"}
+	codeLines := func(string) ([]int, bool) {
+		return []int{1, 2}, true
+	}
+	if err := c.Include("/repo", files, []string{"api/**"}, codeLines); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.CarryForward(prev, []string{"api/**"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"api/**"}, got); diff != "" {
+		t.Error(diff)
+	}
+	var paths []string
+	for _, f := range c.Files {
+		paths = append(paths, f.File)
+	}
+	if diff := cmp.Diff([]string{"api/new.go", "api/handler.go"}, paths); diff != "" {
+		t.Error(diff)
+	}
+	if want := 12; c.Total != want {
+		t.Errorf("got %v\nwant %v", c.Total, want)
+	}
+	if want := 8; c.Covered != want {
+		t.Errorf("got %v\nwant %v", c.Covered, want)
+	}
+}
@@ -109,6 +109,7 @@ func TestCloverParseAllFormat(t *testing.T) {
 		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), false},
 		{filepath.Join(testdataDir(t), "cobertura", "coverage.xml"), true},
 		{filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), true},
+		{filepath.Join(testdataDir(t), "sonar", "coverage.xml"), true},
 	}
 	for _, tt := range tests {
 		_, _, err := NewClover().ParseReport(tt.path)
@@ -2,12 +2,18 @@ package coverage
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 )
 
-var _ Processor = (*Cobertura)(nil)
+var _ StreamProcessor = (*Cobertura)(nil)
 
 const CoberturaDefaultPath = "coverage.xml"
 
@@ -40,35 +46,41 @@ type CoberturaReportPackage struct {
 	BranchRate float64 `xml:"branch-rate,attr"`
 	Complexity int     `xml:"complexity,attr"`
 	Classes    struct {
-		Class []struct {
-			Filename   string  `xml:"filename,attr"`
-			Complexity int     `xml:"complexity,attr"`
+		Class []CoberturaReportClass `xml:"class"`
+	} `xml:"classes"`
+}
+
+type CoberturaReportClass struct {
+	Filename   string  `xml:"filename,attr"`
+	Complexity int     `xml:"complexity,attr"`
+	LineRate   float64 `xml:"line-rate,attr"`
+	BranchRate float64 `xml:"branch-rate,attr"`
+	Methods    struct {
+		Method []struct {
+			Name       string  `xml:"name,attr"`
+			Signature  string  `xml:"signature,attr"`
 			LineRate   float64 `xml:"line-rate,attr"`
 			BranchRate float64 `xml:"branch-rate,attr"`
-			Methods    struct {
-				Method []struct {
-					Name       string  `xml:"name,attr"`
-					Signature  string  `xml:"signature,attr"`
-					LineRate   float64 `xml:"line-rate,attr"`
-					BranchRate float64 `xml:"branch-rate,attr"`
-					Lines      struct {
-						Line []struct {
-							Number int `xml:"number,attr"`
-							Hits   int `xml:"hits,attr"`
-						} `xml:"line"`
-					} `xml:"lines"`
-				}
-			} `xml:"methods"`
-			Lines struct {
-				Line []struct {
-					Number int `xml:"number,attr"`
-					Hits   int `xml:"hits,attr"`
-				} `xml:"line"`
+			Lines      struct {
+				Line []CoberturaReportLine `xml:"line"`
 			} `xml:"lines"`
-		} `xml:"class"`
-	} `xml:"classes"`
+		}
+	} `xml:"methods"`
+	Lines struct {
+		Line []CoberturaReportLine `xml:"line"`
+	} `xml:"lines"`
 }
 
+type CoberturaReportLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr"` // e.g. "50% (1/2)"
+}
+
+// conditionCoverageRe matches the covered and total branches of condition-coverage.
+var conditionCoverageRe = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+
 func NewCobertura() *Cobertura {
 	return &Cobertura{}
 }
@@ -78,64 +90,100 @@ func (c *Cobertura) Name() string {
 }
 
 func (c *Cobertura) ParseReport(path string) (*Coverage, string, error) {
-	rp, err := c.detectReportPath(path)
+	b := NewBuilder()
+	rp, err := c.StreamReport(path, func(fc *FileCoverage) error {
+		b.AddFile(c.Name(), fc)
+		return nil
+	})
 	if err != nil {
 		return nil, "", err
 	}
-	b, err := os.ReadFile(filepath.Clean(rp))
+	cov, err := b.Coverage()
 	if err != nil {
 		return nil, "", err
 	}
-	r := CoberturaReport{}
-	if err := xml.Unmarshal(b, &r); err != nil {
-		return nil, "", err
+	slices.SortStableFunc(cov.Files, func(a, b *FileCoverage) int {
+		return strings.Compare(a.File, b.File)
+	})
+	return cov, rp, nil
+}
+
+// StreamReport decodes the Cobertura report class by class and calls fn with the coverage of each class.
+// Classes of the same file (e.g. inner classes) are passed as separate file coverages.
+func (c *Cobertura) StreamReport(path string, fn func(fc *FileCoverage) error) (string, error) {
+	rp, err := c.detectReportPath(path)
+	if err != nil {
+		return "", err
 	}
-	if r.Packages == nil {
-		return nil, "", fmt.Errorf("%s is not Cobertura format", filepath.Clean(rp))
+	r, err := os.Open(filepath.Clean(rp))
+	if err != nil {
+		return "", err
 	}
-
-	cov := New()
-	cov.Type = TypeLOC
-	cov.Format = c.Name()
-
-	flm := map[string]BlockCoverages{}
-	for _, p := range r.Packages.Package {
-		for _, c := range p.Classes.Class {
-			n := c.Filename
-			f, ok := flm[n]
-			if !ok {
-				f = BlockCoverages{}
+	defer r.Close()
+	d := xml.NewDecoder(r)
+	root := false
+	packages := false
+	for {
+		t, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		se, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if !root {
+			if se.Name.Local != "coverage" {
+				return "", fmt.Errorf("%s is not Cobertura format", filepath.Clean(rp))
 			}
-			for _, l := range c.Lines.Line {
-				sl := l.Number
-				el := l.Number
-				c := toExecCount(l.Hits)
-				f = append(f, &BlockCoverage{
-					Type:      TypeLOC,
-					StartLine: &sl,
-					EndLine:   &el,
-					Count:     &c,
-				})
+			root = true
+			continue
+		}
+		switch se.Name.Local {
+		case "packages":
+			packages = true
+		case "class":
+			cl := CoberturaReportClass{}
+			if err := d.DecodeElement(&cl, &se); err != nil {
+				return "", err
+			}
+			if err := fn(c.fileCoverage(cl)); err != nil {
+				return "", err
 			}
-			flm[n] = f
 		}
 	}
+	if !packages {
+		return "", fmt.Errorf("%s is not Cobertura format", filepath.Clean(rp))
+	}
+	return rp, nil
+}
 
-	for f, blocks := range flm {
-		fcov := NewFileCoverage(f, TypeLOC)
-		for _, b := range blocks {
-			fcov.Total += 1
-			if *b.Count > 0 {
-				fcov.Covered += 1
-			}
+func (c *Cobertura) fileCoverage(cl CoberturaReportClass) *FileCoverage {
+	fcov := NewFileCoverage(cl.Filename, TypeLOC)
+	s := newBlockSlab(len(cl.Lines.Line))
+	fcov.Blocks = make(BlockCoverages, 0, len(cl.Lines.Line))
+	var branches BranchCoverages
+	for _, l := range cl.Lines.Line {
+		count := toExecCount(l.Hits)
+		fcov.Total += 1
+		if count > 0 {
+			fcov.Covered += 1
+		}
+		fcov.Blocks = append(fcov.Blocks, s.lineBlock(l.Number, count))
+		if !l.Branch {
+			continue
+		}
+		if m := conditionCoverageRe.FindStringSubmatch(l.ConditionCoverage); m != nil {
+			covered, _ := strconv.Atoi(m[1]) //nostyle:handlerrors
+			total, _ := strconv.Atoi(m[2])   //nostyle:handlerrors
+			branches = branches.addBranches(l.Number, total, covered)
 		}
-		fcov.Blocks = blocks
-		cov.Total += fcov.Total
-		cov.Covered += fcov.Covered
-		cov.Files = append(cov.Files, fcov)
 	}
-
-	return cov, rp, nil
+	fcov.setBranches(branches)
+	return fcov
 }
 
 func (c *Cobertura) detectReportPath(path string) (string, error) {
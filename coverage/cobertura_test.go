@@ -52,6 +52,7 @@ func TestCoberturaParseAllFormat(t *testing.T) {
 		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), true},
 		{filepath.Join(testdataDir(t), "cobertura", "coverage.xml"), false},
 		{filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), true},
+		{filepath.Join(testdataDir(t), "sonar", "coverage.xml"), true},
 	}
 	for _, tt := range tests {
 		_, _, err := NewCobertura().ParseReport(tt.path)
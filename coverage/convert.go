@@ -0,0 +1,267 @@
+package coverage
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/k1LoW/octocov/version"
+)
+
+var (
+	_ Converter = (*Lcov)(nil)
+	_ Converter = (*Cobertura)(nil)
+	_ Converter = (*Sonar)(nil)
+	_ Converter = (*Gocover)(nil)
+)
+
+// Converter is a Processor that can also write a Coverage as a report of its format.
+// File paths are written as EffectivePath (repository-relative when normalized).
+type Converter interface {
+	Processor
+	WriteReport(w io.Writer, cov *Coverage) error
+}
+
+// WriteReport writes the coverage as an LCOV tracefile.
+func (l *Lcov) WriteReport(w io.Writer, cov *Coverage) error {
+	bw := bufio.NewWriter(w)
+	for _, fc := range cov.Files {
+		_, _ = fmt.Fprintln(bw, "TN:")
+		_, _ = fmt.Fprintf(bw, "SF:%s\n", filepath.ToSlash(fc.EffectivePath()))
+		for _, fn := range fc.Functions {
+			if fn.EndLine > 0 {
+				_, _ = fmt.Fprintf(bw, "FN:%d,%d,%s\n", fn.StartLine, fn.EndLine, fn.Name)
+				continue
+			}
+			_, _ = fmt.Fprintf(bw, "FN:%d,%s\n", fn.StartLine, fn.Name)
+		}
+		for _, fn := range fc.Functions {
+			_, _ = fmt.Fprintf(bw, "FNDA:%d,%s\n", fn.Count, fn.Name)
+		}
+		if len(fc.Functions) > 0 {
+			_, _ = fmt.Fprintf(bw, "FNF:%d\n", fc.Functions.Total())
+			_, _ = fmt.Fprintf(bw, "FNH:%d\n", fc.Functions.Covered())
+		}
+		// The branches of a line are numbered in order. Unless each branch is identified, the covered ones come first.
+		for _, b := range fc.Branches {
+			for i := range b.Total {
+				taken := 0
+				if (b.identified() && b.Taken[i]) || (!b.identified() && i < b.Covered) {
+					taken = 1
+				}
+				_, _ = fmt.Fprintf(bw, "BRDA:%d,0,%d,%d\n", b.Line, i, taken)
+			}
+		}
+		if len(fc.Branches) > 0 {
+			_, _ = fmt.Fprintf(bw, "BRF:%d\n", fc.Branches.Total())
+			_, _ = fmt.Fprintf(bw, "BRH:%d\n", fc.Branches.Covered())
+		}
+		lcs := fc.Blocks.ToLineCoverages()
+		for _, lc := range lcs {
+			_, _ = fmt.Fprintf(bw, "DA:%d,%d\n", lc.Line, lc.Count)
+		}
+		_, _ = fmt.Fprintf(bw, "LF:%d\n", lcs.Total())
+		_, _ = fmt.Fprintf(bw, "LH:%d\n", lcs.Covered())
+		_, _ = fmt.Fprintln(bw, "end_of_record")
+	}
+	return bw.Flush()
+}
+
+type coberturaOutReport struct {
+	XMLName         xml.Name               `xml:"coverage"`
+	LineRate        string                 `xml:"line-rate,attr"`
+	BranchRate      string                 `xml:"branch-rate,attr"`
+	LinesCovered    int                    `xml:"lines-covered,attr"`
+	LinesValid      int                    `xml:"lines-valid,attr"`
+	BranchesCovered int                    `xml:"branches-covered,attr"`
+	BranchesValid   int                    `xml:"branches-valid,attr"`
+	Complexity      int                    `xml:"complexity,attr"`
+	Version         string                 `xml:"version,attr"`
+	Timestamp       int                    `xml:"timestamp,attr"`
+	Sources         []string               `xml:"sources>source"`
+	Packages        []*coberturaOutPackage `xml:"packages>package"`
+}
+
+type coberturaOutPackage struct {
+	Name       string               `xml:"name,attr"`
+	LineRate   string               `xml:"line-rate,attr"`
+	BranchRate string               `xml:"branch-rate,attr"`
+	Complexity int                  `xml:"complexity,attr"`
+	Classes    []*coberturaOutClass `xml:"classes>class"`
+	lines      LineCoverages
+	branches   BranchCoverages
+}
+
+type coberturaOutClass struct {
+	Name       string              `xml:"name,attr"`
+	Filename   string              `xml:"filename,attr"`
+	LineRate   string              `xml:"line-rate,attr"`
+	BranchRate string              `xml:"branch-rate,attr"`
+	Complexity int                 `xml:"complexity,attr"`
+	Methods    struct{}            `xml:"methods"`
+	Lines      []*coberturaOutLine `xml:"lines>line"`
+}
+
+type coberturaOutLine struct {
+	Number            int       `xml:"number,attr"`
+	Hits              ExecCount `xml:"hits,attr"`
+	Branch            bool      `xml:"branch,attr,omitempty"`
+	ConditionCoverage string    `xml:"condition-coverage,attr,omitempty"`
+}
+
+// WriteReport writes the coverage as a Cobertura XML report. Files are grouped into packages by directory.
+func (c *Cobertura) WriteReport(w io.Writer, cov *Coverage) error {
+	r := &coberturaOutReport{
+		Version: version.Version,
+		Sources: []string{"."},
+	}
+	var (
+		allLines    LineCoverages
+		allBranches BranchCoverages
+	)
+	pkgs := map[string]*coberturaOutPackage{}
+	for _, fc := range cov.Files {
+		p := filepath.ToSlash(fc.EffectivePath())
+		dir := path.Dir(p)
+		pkg, ok := pkgs[dir]
+		if !ok {
+			pkg = &coberturaOutPackage{Name: dir}
+			pkgs[dir] = pkg
+			r.Packages = append(r.Packages, pkg)
+		}
+		lcs := fc.Blocks.ToLineCoverages()
+		cl := &coberturaOutClass{
+			Name:       path.Base(p),
+			Filename:   p,
+			LineRate:   coberturaRate(lcs.Covered(), lcs.Total()),
+			BranchRate: coberturaRate(fc.Branches.Covered(), fc.Branches.Total()),
+		}
+		for _, lc := range lcs {
+			l := &coberturaOutLine{Number: lc.Line, Hits: lc.Count}
+			if b, err := fc.Branches.FindByLine(lc.Line); err == nil {
+				l.Branch = true
+				l.ConditionCoverage = fmt.Sprintf("%d%% (%d/%d)", b.Covered*100/b.Total, b.Covered, b.Total)
+			}
+			cl.Lines = append(cl.Lines, l)
+		}
+		pkg.Classes = append(pkg.Classes, cl)
+		pkg.lines = append(pkg.lines, lcs...)
+		pkg.branches = append(pkg.branches, fc.Branches...)
+		allLines = append(allLines, lcs...)
+		allBranches = append(allBranches, fc.Branches...)
+	}
+	sort.SliceStable(r.Packages, func(i, j int) bool {
+		return r.Packages[i].Name < r.Packages[j].Name
+	})
+	for _, pkg := range r.Packages {
+		pkg.LineRate = coberturaRate(pkg.lines.Covered(), pkg.lines.Total())
+		pkg.BranchRate = coberturaRate(pkg.branches.Covered(), pkg.branches.Total())
+	}
+	r.LinesCovered = allLines.Covered()
+	r.LinesValid = allLines.Total()
+	r.LineRate = coberturaRate(r.LinesCovered, r.LinesValid)
+	r.BranchesCovered = allBranches.Covered()
+	r.BranchesValid = allBranches.Total()
+	r.BranchRate = coberturaRate(r.BranchesCovered, r.BranchesValid)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+	if err := enc.Encode(r); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func coberturaRate(covered, total int) string {
+	if total == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%.4f", float64(covered)/float64(total))
+}
+
+type sonarOutFile struct {
+	Path        string                 `xml:"path,attr"`
+	LineToCover []*sonarOutLineToCover `xml:"lineToCover"`
+}
+
+type sonarOutLineToCover struct {
+	LineNumber      int  `xml:"lineNumber,attr"`
+	Covered         bool `xml:"covered,attr"`
+	BranchesToCover *int `xml:"branchesToCover,attr,omitempty"`
+	CoveredBranches *int `xml:"coveredBranches,attr,omitempty"`
+}
+
+// WriteReport writes the coverage as a Sonar generic coverage report.
+func (s *Sonar) WriteReport(w io.Writer, cov *Coverage) error {
+	r := struct {
+		XMLName xml.Name        `xml:"coverage"`
+		Version string          `xml:"version,attr"`
+		File    []*sonarOutFile `xml:"file"`
+	}{
+		Version: sonarGenericCoverageVersion,
+	}
+	for _, fc := range cov.Files {
+		f := &sonarOutFile{Path: filepath.ToSlash(fc.EffectivePath())}
+		for _, lc := range fc.Blocks.ToLineCoverages() {
+			l := &sonarOutLineToCover{LineNumber: lc.Line, Covered: lc.Count > 0}
+			if b, err := fc.Branches.FindByLine(lc.Line); err == nil {
+				// coveredBranches is mandatory when branchesToCover is set, even if it is 0.
+				l.BranchesToCover = &b.Total
+				l.CoveredBranches = &b.Covered
+			}
+			f.LineToCover = append(f.LineToCover, l)
+		}
+		r.File = append(r.File, f)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteReport writes the coverage as a Go cover profile (mode: count).
+// Blocks with columns are written as they are; the others are written per line.
+func (g *Gocover) WriteReport(w io.Writer, cov *Coverage) error {
+	bw := bufio.NewWriter(w)
+	_, _ = fmt.Fprintln(bw, "mode: count")
+	for _, fc := range cov.Files {
+		p := filepath.ToSlash(fc.EffectivePath())
+		if fc.hasColumns() {
+			for _, b := range fc.Blocks {
+				ns := 1
+				if b.NumStmt != nil {
+					ns = *b.NumStmt
+				}
+				_, _ = fmt.Fprintf(bw, "%s:%d.%d,%d.%d %d %d\n", p, *b.StartLine, *b.StartCol, *b.EndLine, *b.EndCol, ns, *b.Count)
+			}
+			continue
+		}
+		for _, lc := range fc.Blocks.ToLineCoverages() {
+			_, _ = fmt.Fprintf(bw, "%s:%d.1,%d.2 1 %d\n", p, lc.Line, lc.Line, lc.Count)
+		}
+	}
+	return bw.Flush()
+}
+
+func (fc *FileCoverage) hasColumns() bool {
+	if len(fc.Blocks) == 0 {
+		return false
+	}
+	for _, b := range fc.Blocks {
+		if b.StartCol == nil || b.EndCol == nil {
+			return false
+		}
+	}
+	return true
+}
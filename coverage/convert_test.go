@@ -0,0 +1,117 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name         string
+		converter    Converter
+		out          string
+		wantBranches bool
+		wantFuncs    bool
+	}{
+		{"lcov", NewLcov(), "lcov.info", true, true},
+		{"cobertura", NewCobertura(), "coverage.xml", true, false},
+		{"sonar", NewSonar(), "coverage.xml", true, false},
+		{"gocover", NewGocover(), "coverage.out", false, false},
+	}
+	srcs := []struct {
+		processor Processor
+		path      string
+	}{
+		{NewLcov(), filepath.Join(testdataDir(t), "lcov_branch", "branch.lcov")},
+		{NewGocover(), filepath.Join(testdataDir(t), "gocover", "coverage.out")},
+		{NewCobertura(), filepath.Join(testdataDir(t), "cobertura_branch", "coverage.xml")},
+	}
+	for _, src := range srcs {
+		want, _, err := src.processor.ParseReport(src.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			t.Run(src.processor.Name()+" to "+tt.name, func(t *testing.T) {
+				out := filepath.Join(t.TempDir(), tt.out)
+				f, err := os.Create(out)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := tt.converter.WriteReport(f, want); err != nil {
+					t.Fatal(err)
+				}
+				if err := f.Close(); err != nil {
+					t.Fatal(err)
+				}
+				got, _, err := tt.converter.ParseReport(out)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(got.Files) != len(want.Files) {
+					t.Fatalf("got %v\nwant %v", len(got.Files), len(want.Files))
+				}
+				for _, wf := range want.Files {
+					gf, err := got.Files.FindByFile(wf.File)
+					if err != nil {
+						t.Fatal(err)
+					}
+					wl := wf.Blocks.ToLineCoverages()
+					gl := gf.Blocks.ToLineCoverages()
+					if gl.Total() != wl.Total() {
+						t.Errorf("%s: got %v\nwant %v", wf.File, gl.Total(), wl.Total())
+					}
+					if gl.Covered() != wl.Covered() {
+						t.Errorf("%s: got %v\nwant %v", wf.File, gl.Covered(), wl.Covered())
+					}
+				}
+				if tt.wantBranches {
+					if got.BranchTotal != want.BranchTotal {
+						t.Errorf("got %v\nwant %v", got.BranchTotal, want.BranchTotal)
+					}
+					if got.BranchCovered != want.BranchCovered {
+						t.Errorf("got %v\nwant %v", got.BranchCovered, want.BranchCovered)
+					}
+				}
+				if tt.wantFuncs {
+					if got.FunctionTotal != want.FunctionTotal {
+						t.Errorf("got %v\nwant %v", got.FunctionTotal, want.FunctionTotal)
+					}
+					if got.FunctionCovered != want.FunctionCovered {
+						t.Errorf("got %v\nwant %v", got.FunctionCovered, want.FunctionCovered)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestConvertGocoverKeepsBlocks(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "gocover", "coverage.out")
+	want, _, err := NewGocover().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "coverage.out")
+	f, err := os.Create(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewGocover().WriteReport(f, want); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := NewGocover().ParseReport(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != want.Total {
+		t.Errorf("got %v\nwant %v", got.Total, want.Total)
+	}
+	if got.Covered != want.Covered {
+		t.Errorf("got %v\nwant %v", got.Covered, want.Covered)
+	}
+}
@@ -35,11 +35,15 @@ const (
 )
 
 type Coverage struct {
-	Type    Type          `json:"type"`
-	Format  string        `json:"format"`
-	Total   int           `json:"total"`
-	Covered int           `json:"covered"`
-	Files   FileCoverages `json:"files"`
+	Type            Type          `json:"type"`
+	Format          string        `json:"format"`
+	Total           int           `json:"total"`
+	Covered         int           `json:"covered"`
+	BranchTotal     int           `json:"branch_total,omitempty"`
+	BranchCovered   int           `json:"branch_covered,omitempty"`
+	FunctionTotal   int           `json:"function_total,omitempty"`
+	FunctionCovered int           `json:"function_covered,omitempty"`
+	Files           FileCoverages `json:"files"`
 }
 
 type FileCoverage struct {
@@ -51,11 +55,21 @@ type FileCoverage struct {
 	// NormalizedPath unifies them to git-root-relative paths (e.g., "cmd/main.go") so that
 	// matching, merging, comparing, and excluding work correctly across formats.
 	// File retains the original parser-produced path for backward compatibility with stored report.json.
-	NormalizedPath string         `json:"normalized_path,omitempty"`
-	Total          int            `json:"total"`
-	Covered        int            `json:"covered"`
-	Blocks         BlockCoverages `json:"blocks,omitempty"`
+	NormalizedPath  string            `json:"normalized_path,omitempty"`
+	Total           int               `json:"total"`
+	Covered         int               `json:"covered"`
+	BranchTotal     int               `json:"branch_total,omitempty"`
+	BranchCovered   int               `json:"branch_covered,omitempty"`
+	FunctionTotal   int               `json:"function_total,omitempty"`
+	FunctionCovered int               `json:"function_covered,omitempty"`
+	Blocks          BlockCoverages    `json:"blocks,omitempty"`
+	Branches        BranchCoverages   `json:"branches,omitempty"`
+	Functions       FunctionCoverages `json:"functions,omitempty"`
+	// CarriedForward is set to the files carried forward from the previous report, whose Total and Covered are kept as they were.
+	CarriedForward bool `json:"carried_forward,omitempty"`
 	cache          map[int]BlockCoverages
+	// included is set to the files added by Include, which are not measured by any coverage report.
+	included bool
 }
 
 func NewFileCoverage(file string, coverageType Type) *FileCoverage { //nostyle:repetition
@@ -198,6 +212,8 @@ type BlockCoverage struct {
 	EndCol    *int       `json:"end_col,omitempty"`
 	NumStmt   *int       `json:"num_stmt,omitempty"`
 	Count     *ExecCount `json:"count,omitempty"`
+	// Label is the label of the test suite the count of the block is attributed to.
+	Label string `json:"label,omitempty"`
 }
 
 // blockCoverageJSON mirrors BlockCoverage with the canonical "count_u64"
@@ -211,6 +227,7 @@ type blockCoverageJSON struct {
 	NumStmt   *int       `json:"num_stmt,omitempty"`
 	Count     *ExecCount `json:"count,omitempty"`
 	CountU64  *uint64    `json:"count_u64,omitempty"`
+	Label     string     `json:"label,omitempty"`
 }
 
 func (bc *BlockCoverage) MarshalJSON() ([]byte, error) {
@@ -222,6 +239,7 @@ func (bc *BlockCoverage) MarshalJSON() ([]byte, error) {
 		EndCol:    bc.EndCol,
 		NumStmt:   bc.NumStmt,
 		Count:     bc.Count,
+		Label:     bc.Label,
 	}
 	if bc.Count != nil {
 		raw := uint64(*bc.Count)
@@ -242,6 +260,7 @@ func (bc *BlockCoverage) UnmarshalJSON(data []byte) error {
 	bc.EndCol = a.EndCol
 	bc.NumStmt = a.NumStmt
 	bc.Count = a.Count
+	bc.Label = a.Label
 	if a.CountU64 != nil {
 		c := ExecCount(*a.CountU64)
 		bc.Count = &c
@@ -251,6 +270,8 @@ func (bc *BlockCoverage) UnmarshalJSON(data []byte) error {
 
 type BlockCoverages []*BlockCoverage
 
+// Processor parses coverage reports of a format.
+// Reports are parsed concurrently, so a Processor must be safe for concurrent use.
 type Processor interface {
 	Name() string
 	ParseReport(path string) (*Coverage, string, error)
@@ -265,6 +286,8 @@ func New() *Coverage {
 func (c *Coverage) DeleteBlockCoverages() {
 	for _, f := range c.Files {
 		f.Blocks = BlockCoverages{}
+		f.Branches = nil
+		f.Functions = nil
 	}
 }
 
@@ -326,6 +349,30 @@ func (fc *FileCoverage) FindBlocksByLine(n int) BlockCoverages {
 	}
 }
 
+// CountLines returns the number of the lines that are coverable, and the number of them covered.
+// Lines without coverage data (e.g. blank lines or comments) are not coverable.
+func (fc *FileCoverage) CountLines(lines []int) (int, int) {
+	if fc == nil || len(lines) == 0 {
+		return 0, 0
+	}
+	counts := map[int]ExecCount{}
+	for _, lc := range fc.Blocks.lineCounts() {
+		counts[lc.line] = lc.count
+	}
+	total, covered := 0, 0
+	for _, l := range lines {
+		c, ok := counts[l]
+		if !ok {
+			continue
+		}
+		total++
+		if c > 0 {
+			covered++
+		}
+	}
+	return total, covered
+}
+
 func (dc DiffFileCoverages) FuzzyFindByFile(file string) (*DiffFileCoverage, error) { //nostyle:recvtype
 	var match *DiffFileCoverage
 	for _, c := range dc {
@@ -421,6 +468,8 @@ type LineCoverage struct {
 	Line         int
 	Count        ExecCount
 	PosCoverages PosCoverages
+	// Partial reports whether the line is executed but some of its columns or branches are not.
+	Partial bool
 }
 
 type LineCoverages []*LineCoverage
@@ -448,6 +497,35 @@ func (lc LineCoverages) Covered() int { //nostyle:recvtype
 	return covered
 }
 
+// Partial returns the number of the lines partially covered.
+func (lc LineCoverages) Partial() int { //nostyle:recvtype
+	partial := 0
+	for _, c := range lc {
+		if c.Partial {
+			partial += 1
+		}
+	}
+	return partial
+}
+
+// ToLineCoverages returns the line coverages of the file.
+// In addition to the lines partially covered by the columns of the blocks, the lines executed but missing some of their branches are partial.
+func (fc *FileCoverage) ToLineCoverages() LineCoverages {
+	lcs := fc.Blocks.ToLineCoverages()
+	if len(fc.Branches) == 0 {
+		return lcs
+	}
+	for _, lc := range lcs {
+		if lc.Count == 0 || lc.Partial {
+			continue
+		}
+		if b, err := fc.Branches.FindByLine(lc.Line); err == nil && b.Covered < b.Total {
+			lc.Partial = true
+		}
+	}
+	return lcs
+}
+
 func (bc BlockCoverages) ToLineCoverages() LineCoverages { //nostyle:recvtype
 	m := skipmap.NewInt[*skipmap.IntMap[ExecCount]]()
 
@@ -555,6 +633,7 @@ func (bc BlockCoverages) ToLineCoverages() LineCoverages { //nostyle:recvtype
 			Count:        0,
 			PosCoverages: PosCoverages{},
 		}
+		missed := false
 		mm.Range(func(pos int, c ExecCount) bool {
 			lc.PosCoverages = append(lc.PosCoverages, &PosCoverage{
 				Pos:   pos,
@@ -563,8 +642,12 @@ func (bc BlockCoverages) ToLineCoverages() LineCoverages { //nostyle:recvtype
 			if c > lc.Count {
 				lc.Count = c
 			}
+			if c == 0 {
+				missed = true
+			}
 			return true
 		})
+		lc.Partial = lc.Count > 0 && missed
 		lcs = append(lcs, lc)
 		return true
 	})
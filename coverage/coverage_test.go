@@ -90,6 +90,99 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+func TestCompareLineDeltas(t *testing.T) {
+	a := &Coverage{
+		Files: FileCoverages{
+			&FileCoverage{File: "file_a.go", Blocks: BlockCoverages{
+				newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+				newBlockCoverage(TypeLOC, 2, -1, 2, -1, -1, 0),
+				newBlockCoverage(TypeLOC, 3, -1, 3, -1, -1, 0),
+				newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 2),
+				newBlockCoverage(TypeLOC, 5, -1, 5, -1, -1, 0),
+			}},
+			&FileCoverage{File: "file_b.go", Total: 1, Covered: 1},
+		},
+	}
+	b := &Coverage{
+		Files: FileCoverages{
+			&FileCoverage{File: "file_a.go", Blocks: BlockCoverages{
+				newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+				newBlockCoverage(TypeLOC, 2, -1, 2, -1, -1, 3),
+				newBlockCoverage(TypeLOC, 3, -1, 3, -1, -1, 1),
+				newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 0),
+			}},
+			&FileCoverage{File: "file_b.go", Blocks: BlockCoverages{
+				newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 0),
+			}},
+		},
+	}
+	d := a.Compare(b)
+	got, err := d.Files.FuzzyFindByFile("file_a.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got.NewlyUncovered, []int{2, 3}); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(got.NewlyCovered, []int{4}); diff != "" {
+		t.Error(diff)
+	}
+	// The first line of the previous file_a.go is removed, and a line is added above the others.
+	got.MapLineDeltas(func(line int) (int, bool) {
+		if line == 1 {
+			return 0, false
+		}
+		return line + 1, true
+	})
+	if diff := cmp.Diff(got.NewlyUncovered, []int{3}); diff != "" {
+		t.Error(diff)
+	}
+	if got.NewlyCovered != nil {
+		t.Errorf("got %v, want no lines", got.NewlyCovered)
+	}
+	// Lines are not compared without the blocks of both sides.
+	got, err = d.Files.FuzzyFindByFile("file_b.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.NewlyUncovered != nil || got.NewlyCovered != nil {
+		t.Errorf("got %v and %v, want no lines", got.NewlyUncovered, got.NewlyCovered)
+	}
+}
+
+func TestPartialLines(t *testing.T) {
+	fc := &FileCoverage{
+		Blocks: BlockCoverages{
+			// Line 1 is covered only by the first statement.
+			newBlockCoverage(TypeStmt, 1, 2, 1, 10, 1, 1),
+			newBlockCoverage(TypeStmt, 1, 12, 1, 20, 1, 0),
+			newBlockCoverage(TypeStmt, 2, 2, 2, 10, 1, 1),
+			newBlockCoverage(TypeStmt, 3, 2, 3, 10, 1, 1),
+			newBlockCoverage(TypeStmt, 4, 2, 4, 10, 1, 0),
+		},
+		Branches: BranchCoverages{
+			&BranchCoverage{Line: 3, Total: 2, Covered: 1},
+			&BranchCoverage{Line: 4, Total: 2, Covered: 0},
+		},
+	}
+	lcs := fc.ToLineCoverages()
+	var got []int
+	for _, lc := range lcs {
+		if lc.Partial {
+			got = append(got, lc.Line)
+		}
+	}
+	if diff := cmp.Diff(got, []int{1, 3}); diff != "" {
+		t.Error(diff)
+	}
+	if got := lcs.Partial(); got != 2 {
+		t.Errorf("got %v\nwant %v", got, 2)
+	}
+	if got := fc.Blocks.ToLineCoverages().Partial(); got != 1 {
+		t.Errorf("got %v\nwant %v", got, 1)
+	}
+}
+
 func TestMaxCount(t *testing.T) {
 	tests := []struct {
 		blocks BlockCoverages
@@ -232,7 +325,7 @@ func TestToLineCoverages(t *testing.T) {
 			},
 			LineCoverages{
 				&LineCoverage{Line: 6, Count: 7, PosCoverages: PosCoverages{&PosCoverage{Pos: 1, Count: 7}, &PosCoverage{Pos: endPos, Count: 7}}},
-				&LineCoverage{Line: 7, Count: 7, PosCoverages: PosCoverages{&PosCoverage{Pos: startPos, Count: 7}, &PosCoverage{Pos: 1, Count: 7}, &PosCoverage{Pos: 3, Count: 7}, &PosCoverage{Pos: 5, Count: 0}, &PosCoverage{Pos: endPos, Count: 0}}},
+				&LineCoverage{Line: 7, Count: 7, PosCoverages: PosCoverages{&PosCoverage{Pos: startPos, Count: 7}, &PosCoverage{Pos: 1, Count: 7}, &PosCoverage{Pos: 3, Count: 7}, &PosCoverage{Pos: 5, Count: 0}, &PosCoverage{Pos: endPos, Count: 0}}, Partial: true},
 				&LineCoverage{Line: 8, Count: 0, PosCoverages: PosCoverages{&PosCoverage{Pos: startPos, Count: 0}, &PosCoverage{Pos: 3, Count: 0}}},
 			},
 			3,
@@ -426,3 +519,30 @@ func newBlockCoverage(t Type, sl, sc, el, ec, ns, c int) *BlockCoverage {
 
 	return bc
 }
+
+func TestCountLines(t *testing.T) {
+	fc := &FileCoverage{
+		Blocks: BlockCoverages{
+			newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+			newBlockCoverage(TypeLOC, 2, -1, 2, -1, -1, 0),
+			newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 3),
+			newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 0),
+		},
+	}
+	tests := []struct {
+		lines       []int
+		wantTotal   int
+		wantCovered int
+	}{
+		{nil, 0, 0},
+		{[]int{3, 5}, 0, 0},
+		{[]int{1, 2, 3, 4}, 3, 2},
+		{[]int{2}, 1, 0},
+	}
+	for _, tt := range tests {
+		total, covered := fc.CountLines(tt.lines)
+		if total != tt.wantTotal || covered != tt.wantCovered {
+			t.Errorf("%v: got %d/%d\nwant %d/%d", tt.lines, covered, total, tt.wantCovered, tt.wantTotal)
+		}
+	}
+}
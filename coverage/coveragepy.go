@@ -0,0 +1,156 @@
+package coverage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/goccy/go-json"
+)
+
+var _ Processor = (*CoveragePy)(nil)
+
+const CoveragePyDefaultPath = "coverage.json"
+
+type CoveragePy struct{}
+
+// CoveragePyReport is the JSON report written by `coverage json` of coverage.py.
+type CoveragePyReport struct {
+	Meta  *CoveragePyReportMeta            `json:"meta"`
+	Files map[string]*CoveragePyReportFile `json:"files"`
+}
+
+type CoveragePyReportMeta struct {
+	Format         int    `json:"format"`
+	Version        string `json:"version"`
+	Timestamp      string `json:"timestamp"`
+	BranchCoverage bool   `json:"branch_coverage"`
+	ShowContexts   bool   `json:"show_contexts"`
+}
+
+type CoveragePyReportFile struct {
+	ExecutedLines []int `json:"executed_lines"`
+	MissingLines  []int `json:"missing_lines"`
+	ExcludedLines []int `json:"excluded_lines"`
+	// Branch arcs are recorded as [from, to] line pairs when measured with --branch.
+	// A negative `to` means an exit from the code object.
+	ExecutedBranches [][]int `json:"executed_branches,omitempty"`
+	MissingBranches  [][]int `json:"missing_branches,omitempty"`
+}
+
+func NewCoveragePy() *CoveragePy {
+	return &CoveragePy{}
+}
+
+func (c *CoveragePy) Name() string {
+	return "coverage.py"
+}
+
+func (c *CoveragePy) ParseReport(path string) (*Coverage, string, error) {
+	rp, err := c.detectReportPath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := os.ReadFile(filepath.Clean(rp))
+	if err != nil {
+		return nil, "", err
+	}
+	r := CoveragePyReport{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, "", err
+	}
+	if r.Meta == nil || r.Files == nil {
+		return nil, "", fmt.Errorf("%s is not coverage.py format", filepath.Clean(rp))
+	}
+
+	cov := New()
+	cov.Type = TypeLOC
+	cov.Format = c.Name()
+
+	names := make([]string, 0, len(r.Files))
+	for n := range r.Files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		f := r.Files[n]
+		if f == nil {
+			continue
+		}
+		excluded := map[int]struct{}{}
+		for _, l := range f.ExcludedLines {
+			excluded[l] = struct{}{}
+		}
+		fcov := NewFileCoverage(n, TypeLOC)
+		// coverage.py does not record execution counts.
+		for _, l := range f.ExecutedLines {
+			if _, ok := excluded[l]; ok {
+				continue
+			}
+			fcov.Blocks = append(fcov.Blocks, newCoveragePyBlock(l, 1))
+		}
+		for _, l := range f.MissingLines {
+			if _, ok := excluded[l]; ok {
+				continue
+			}
+			fcov.Blocks = append(fcov.Blocks, newCoveragePyBlock(l, 0))
+		}
+		// Each arc from a line is a branch of the line.
+		var branches BranchCoverages
+		for _, a := range f.ExecutedBranches {
+			if len(a) != 2 {
+				continue
+			}
+			if _, ok := excluded[a[0]]; ok {
+				continue
+			}
+			branches = branches.addBranches(a[0], 1, 1)
+		}
+		for _, a := range f.MissingBranches {
+			if len(a) != 2 {
+				continue
+			}
+			if _, ok := excluded[a[0]]; ok {
+				continue
+			}
+			branches = branches.addBranches(a[0], 1, 0)
+		}
+		lcs := fcov.Blocks.ToLineCoverages()
+		fcov.Total = lcs.Total()
+		fcov.Covered = lcs.Covered()
+		fcov.setBranches(branches)
+		cov.Total += fcov.Total
+		cov.Covered += fcov.Covered
+		cov.BranchTotal += fcov.BranchTotal
+		cov.BranchCovered += fcov.BranchCovered
+		cov.Files = append(cov.Files, fcov)
+	}
+
+	return cov, rp, nil
+}
+
+func newCoveragePyBlock(line int, c ExecCount) *BlockCoverage {
+	sl := line
+	el := line
+	return &BlockCoverage{
+		Type:      TypeLOC,
+		StartLine: &sl,
+		EndLine:   &el,
+		Count:     &c,
+	}
+}
+
+func (c *CoveragePy) detectReportPath(path string) (string, error) {
+	p, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if p.IsDir() {
+		path = filepath.Join(path, CoveragePyDefaultPath)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,75 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCoveragePy(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "coveragepy")
+	got, _, err := NewCoveragePy().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 9; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 7; got.Covered != want {
+		t.Errorf("got %v\nwant %v", got.Covered, want)
+	}
+	if want := 2; len(got.Files) != want {
+		t.Fatalf("got %v\nwant %v", len(got.Files), want)
+	}
+	fc, err := got.Files.FindByFile("app/calc.py")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// excluded lines are not coverable.
+	for _, l := range []int{12, 13} {
+		if blocks := fc.FindBlocksByLine(l); len(blocks) != 0 {
+			t.Errorf("line %d: got %v blocks\nwant 0", l, len(blocks))
+		}
+	}
+	for _, f := range got.Files {
+		total := 0
+		covered := 0
+		for _, b := range f.Blocks {
+			// LOC
+			total = total + 1
+			if *b.Count > 0 {
+				covered += 1
+			}
+		}
+		if got := f.Total; got != total {
+			t.Errorf("got %v\nwant %v", got, total)
+		}
+		if got := f.Covered; got != covered {
+			t.Errorf("got %v\nwant %v", got, covered)
+		}
+	}
+}
+
+func TestCoveragePyParseAllFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{filepath.Join(testdataDir(t), "gocover", "coverage.out"), true},
+		{filepath.Join(testdataDir(t), "lcov", "lcov.info"), true},
+		{filepath.Join(testdataDir(t), "simplecov", ".resultset.json"), true},
+		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "cobertura", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), true},
+		{filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json"), true},
+		{filepath.Join(testdataDir(t), "coveragepy", "coverage.json"), false},
+		{filepath.Join(testdataDir(t), "opencover", "coverage.opencover.xml"), true},
+		{filepath.Join(testdataDir(t), "gcov", "main.gcov.json.gz"), true},
+		{filepath.Join(testdataDir(t), "sonar", "coverage.xml"), true},
+	}
+	for _, tt := range tests {
+		_, _, err := NewCoveragePy().ParseReport(tt.path)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+		}
+	}
+}
@@ -1,21 +1,29 @@
 package coverage
 
 type DiffCoverage struct {
-	A         float64           `json:"a"`
-	B         float64           `json:"b"`
-	Diff      float64           `json:"diff"`
-	CoverageA *Coverage         `json:"-"`
-	CoverageB *Coverage         `json:"-"`
-	Files     DiffFileCoverages `json:"files"`
+	A            float64           `json:"a"`
+	B            float64           `json:"b"`
+	Diff         float64           `json:"diff"`
+	BranchA      float64           `json:"branch_a,omitempty"`
+	BranchB      float64           `json:"branch_b,omitempty"`
+	BranchDiff   float64           `json:"branch_diff,omitempty"`
+	FunctionA    float64           `json:"function_a,omitempty"`
+	FunctionB    float64           `json:"function_b,omitempty"`
+	FunctionDiff float64           `json:"function_diff,omitempty"`
+	CoverageA    *Coverage         `json:"-"`
+	CoverageB    *Coverage         `json:"-"`
+	Files        DiffFileCoverages `json:"files"`
 }
 
 type DiffFileCoverage struct {
-	File          string        `json:"file"`
-	A             float64       `json:"a"`
-	B             float64       `json:"b"`
-	Diff          float64       `json:"diff"`
-	FileCoverageA *FileCoverage `json:"-"`
-	FileCoverageB *FileCoverage `json:"-"`
+	File           string        `json:"file"`
+	A              float64       `json:"a"`
+	B              float64       `json:"b"`
+	Diff           float64       `json:"diff"`
+	NewlyUncovered []int         `json:"newly_uncovered,omitempty"`
+	NewlyCovered   []int         `json:"newly_covered,omitempty"`
+	FileCoverageA  *FileCoverage `json:"-"`
+	FileCoverageB  *FileCoverage `json:"-"`
 }
 
 type DiffFileCoverages []*DiffFileCoverage
@@ -38,6 +46,12 @@ func (c *Coverage) Compare(c2 *Coverage) *DiffCoverage {
 	d.A = coverA
 	d.B = coverB
 	d.Diff = coverA - coverB
+	d.BranchA = c.BranchPercent()
+	d.BranchB = c2.BranchPercent()
+	d.BranchDiff = d.BranchA - d.BranchB
+	d.FunctionA = c.FunctionPercent()
+	d.FunctionB = c2.FunctionPercent()
+	d.FunctionDiff = d.FunctionA - d.FunctionB
 
 	// m maps path keys to DiffFileCoverage. A single DiffFileCoverage may be
 	// registered under multiple keys (EffectivePath and File) so that lookups
@@ -88,12 +102,29 @@ func (c *Coverage) Compare(c2 *Coverage) *DiffCoverage {
 		dfc.A = coverA
 		dfc.B = coverB
 		dfc.Diff = coverA - coverB
+		dfc.NewlyUncovered, dfc.NewlyCovered = lineDeltas(dfc.FileCoverageA, dfc.FileCoverageB, nil)
 		d.Files = append(d.Files, dfc)
 	}
 
 	return d
 }
 
+// IsMeasuredBranch reports whether either side of the comparison has branch coverage.
+func (d *DiffCoverage) IsMeasuredBranch() bool {
+	return (d.CoverageA != nil && d.CoverageA.BranchTotal > 0) || (d.CoverageB != nil && d.CoverageB.BranchTotal > 0)
+}
+
+// IsMeasuredFunction reports whether either side of the comparison has function coverage.
+func (d *DiffCoverage) IsMeasuredFunction() bool {
+	return (d.CoverageA != nil && d.CoverageA.FunctionTotal > 0) || (d.CoverageB != nil && d.CoverageB.FunctionTotal > 0)
+}
+
+// MapLineDeltas recomputes NewlyUncovered and NewlyCovered with the lines of FileCoverageB mapped to the lines of FileCoverageA by mapLine.
+// mapLine returns false for the lines that have no counterpart (e.g. removed lines).
+func (dfc *DiffFileCoverage) MapLineDeltas(mapLine func(line int) (int, bool)) {
+	dfc.NewlyUncovered, dfc.NewlyCovered = lineDeltas(dfc.FileCoverageA, dfc.FileCoverageB, mapLine)
+}
+
 // lookupDiffMap tries to find an existing DiffFileCoverage by effectivePath first, then by file.
 func lookupDiffMap(m map[string]*DiffFileCoverage, effectivePath, file string) *DiffFileCoverage {
 	if dfc, ok := m[effectivePath]; ok {
@@ -106,3 +137,37 @@ func lookupDiffMap(m map[string]*DiffFileCoverage, effectivePath, file string) *
 	}
 	return nil
 }
+
+// lineDeltas returns the lines covered only in fc2 and the lines covered only in fc, in order of lines.
+// Only the lines coverable in both are compared, and nothing is returned unless both have blocks.
+// The lines of fc2 are mapped to the lines of fc by mapLine if it is not nil.
+func lineDeltas(fc, fc2 *FileCoverage, mapLine func(line int) (int, bool)) ([]int, []int) {
+	if fc == nil || fc2 == nil || len(fc.Blocks) == 0 || len(fc2.Blocks) == 0 {
+		return nil, nil
+	}
+	counts2 := map[int]ExecCount{}
+	for _, lc := range fc2.Blocks.lineCounts() {
+		l := lc.line
+		if mapLine != nil {
+			var ok bool
+			if l, ok = mapLine(l); !ok {
+				continue
+			}
+		}
+		counts2[l] = lc.count
+	}
+	var uncovered, covered []int
+	for _, lc := range fc.Blocks.lineCounts() {
+		c2, ok := counts2[lc.line]
+		if !ok {
+			continue
+		}
+		switch {
+		case lc.count == 0 && c2 > 0:
+			uncovered = append(uncovered, lc.line)
+		case lc.count > 0 && c2 == 0:
+			covered = append(covered, lc.line)
+		}
+	}
+	return uncovered, covered
+}
@@ -14,33 +14,9 @@ func (c *Coverage) Exclude(exclude []string) error {
 	// Exclude files
 	var files FileCoverages
 	for i, f := range c.Files {
-		excluded := false
-		for _, e := range exclude {
-			not := false
-			if rest, found := strings.CutPrefix(e, "!"); found {
-				e = rest
-				not = true
-			}
-			match, err := doublestar.Match(e, f.EffectivePath())
-			if err != nil {
-				return err
-			}
-			// Also try matching against the original File path so that
-			// existing patterns using module paths (e.g. "github.com/owner/repo/pkg/*.go")
-			// continue to work after normalization.
-			if !match && f.EffectivePath() != f.File {
-				match, err = doublestar.Match(e, f.File)
-				if err != nil {
-					return err
-				}
-			}
-			if match {
-				if not {
-					excluded = false
-				} else {
-					excluded = true
-				}
-			}
+		excluded, err := f.Match(exclude)
+		if err != nil {
+			return err
 		}
 		if !excluded {
 			files = append(files, c.Files[i])
@@ -50,3 +26,48 @@ func (c *Coverage) Exclude(exclude []string) error {
 
 	return c.reCalc()
 }
+
+// Select returns the files matching the patterns.
+func (fc FileCoverages) Select(patterns []string) (FileCoverages, error) { //nostyle:recvtype
+	var files FileCoverages
+	for _, f := range fc {
+		match, err := f.Match(patterns)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// Match reports whether the file matches the doublestar patterns.
+// A pattern prefixed with `!` unmatches the file, and the last pattern matching the file wins.
+func (fc *FileCoverage) Match(patterns []string) (bool, error) {
+	matched := false
+	for _, e := range patterns {
+		not := false
+		if rest, found := strings.CutPrefix(e, "!"); found {
+			e = rest
+			not = true
+		}
+		match, err := doublestar.Match(e, fc.EffectivePath())
+		if err != nil {
+			return false, err
+		}
+		// Also try matching against the original File path so that
+		// existing patterns using module paths (e.g. "github.com/owner/repo/pkg/*.go")
+		// continue to work after normalization.
+		if !match && fc.EffectivePath() != fc.File {
+			match, err = doublestar.Match(e, fc.File)
+			if err != nil {
+				return false, err
+			}
+		}
+		if match {
+			matched = !not
+		}
+	}
+	return matched, nil
+}
@@ -220,3 +220,36 @@ func TestExclude(t *testing.T) {
 		}
 	}
 }
+
+func TestSelect(t *testing.T) {
+	files := FileCoverages{
+		&FileCoverage{File: "api/handler.go"},
+		&FileCoverage{File: "api/mock/handler.go"},
+		&FileCoverage{File: "github.com/owner/repo/web/app.go", NormalizedPath: "web/app.go"},
+		&FileCoverage{File: "lib/util.go"},
+	}
+	tests := []struct {
+		patterns []string
+		want     []string
+	}{
+		{[]string{}, nil},
+		{[]string{"api/**"}, []string{"api/handler.go", "api/mock/handler.go"}},
+		{[]string{"api/**", "!api/mock/**"}, []string{"api/handler.go"}},
+		{[]string{"web/**"}, []string{"github.com/owner/repo/web/app.go"}},
+		{[]string{"github.com/owner/repo/**"}, []string{"github.com/owner/repo/web/app.go"}},
+		{[]string{"**/*.go", "!lib/**"}, []string{"api/handler.go", "api/mock/handler.go", "github.com/owner/repo/web/app.go"}},
+	}
+	for _, tt := range tests {
+		got, err := files.Select(tt.patterns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gotFiles []string
+		for _, f := range got {
+			gotFiles = append(gotFiles, f.File)
+		}
+		if diff := cmp.Diff(tt.want, gotFiles); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package coverage
+
+import "sort"
+
+// span is a source range with an execution count, as reported by formats whose ranges
+// may nest (e.g. an Istanbul `if` statement containing the statements of its body).
+// Lines and columns are 1-based and both ends are inclusive.
+type span struct {
+	startLine int
+	startCol  int
+	endLine   int
+	endCol    int
+	numStmt   int
+	count     ExecCount
+}
+
+type point struct {
+	line int
+	col  int
+}
+
+func (p point) less(p2 point) bool {
+	if p.line != p2.line {
+		return p.line < p2.line
+	}
+	return p.col < p2.col
+}
+
+func (s span) start() point {
+	return point{s.startLine, s.startCol}
+}
+
+// end returns the exclusive end point of the span.
+func (s span) end() point {
+	return point{s.endLine, s.endCol + 1}
+}
+
+// flattenSpans converts possibly nested spans into non-overlapping TypeStmt blocks.
+// Each position takes the count of the innermost span containing it, so that an uncovered
+// statement inside a covered one stays uncovered instead of being summed up by ToLineCoverages.
+// The NumStmt of a span is attributed to the block that begins at the span's start.
+func flattenSpans(spans []span) BlockCoverages {
+	blocks := BlockCoverages{}
+	if len(spans) == 0 {
+		return blocks
+	}
+	sorted := make([]span, len(spans))
+	copy(sorted, spans)
+	// Outer spans first so that inner spans starting at the same point end up on top of the stack.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		si, sj := sorted[i].start(), sorted[j].start()
+		if si != sj {
+			return si.less(sj)
+		}
+		return sorted[j].end().less(sorted[i].end())
+	})
+
+	var points []point
+	for _, s := range sorted {
+		points = append(points, s.start(), s.end())
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].less(points[j]) })
+
+	var stack []span
+	next := 0
+	for i, p := range points {
+		if i > 0 && points[i-1] == p {
+			continue
+		}
+		active := stack[:0]
+		for _, s := range stack {
+			if p.less(s.end()) {
+				active = append(active, s)
+			}
+		}
+		stack = active
+		numStmt := 0
+		for next < len(sorted) && sorted[next].start() == p {
+			stack = append(stack, sorted[next])
+			numStmt += sorted[next].numStmt
+			next++
+		}
+		if len(stack) == 0 {
+			continue
+		}
+		q := p
+		for _, pp := range points[i+1:] {
+			if pp != p {
+				q = pp
+				break
+			}
+		}
+		if q == p {
+			continue
+		}
+		top := stack[len(stack)-1]
+		sl := p.line
+		sc := p.col
+		el := q.line
+		ec := q.col - 1
+		if ec < 1 {
+			// The next point is at the head of a line, so the block ends at the end of the previous line.
+			el--
+			ec = endPos - 1
+		}
+		ns := numStmt
+		c := top.count
+		blocks = append(blocks, &BlockCoverage{
+			Type:      TypeStmt,
+			StartLine: &sl,
+			StartCol:  &sc,
+			EndLine:   &el,
+			EndCol:    &ec,
+			NumStmt:   &ns,
+			Count:     &c,
+		})
+	}
+	return blocks
+}
@@ -0,0 +1,83 @@
+package coverage
+
+import (
+	"testing"
+)
+
+func TestFlattenSpans(t *testing.T) {
+	// if (x) {       // line 1, count 1
+	//   foo();       // line 2, count 0
+	// }              // line 3
+	spans := []span{
+		{startLine: 1, startCol: 1, endLine: 3, endCol: 1, numStmt: 1, count: 1},
+		{startLine: 2, startCol: 3, endLine: 2, endCol: 8, numStmt: 1, count: 0},
+	}
+	blocks := flattenSpans(spans)
+	if want := 3; len(blocks) != want {
+		t.Fatalf("got %v\nwant %v", len(blocks), want)
+	}
+	total := 0
+	for _, b := range blocks {
+		total += *b.NumStmt
+	}
+	if want := 2; total != want {
+		t.Errorf("got %v\nwant %v", total, want)
+	}
+
+	lcs := blocks.ToLineCoverages()
+	tests := []struct {
+		line int
+		want ExecCount
+	}{
+		{1, 1},
+		{2, 1},
+		{3, 1},
+	}
+	for _, tt := range tests {
+		lc, err := lcs.FindByLine(tt.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lc.Count != tt.want {
+			t.Errorf("line %d: got %v\nwant %v", tt.line, lc.Count, tt.want)
+		}
+	}
+	lc, err := lcs.FindByLine(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for pos := 3; pos <= 8; pos++ {
+		c, err := lc.PosCoverages.FindCountByPos(pos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != 0 {
+			t.Errorf("pos %d: got %v\nwant 0", pos, c)
+		}
+	}
+	if c, err := lc.PosCoverages.FindCountByPos(9); err != nil || c != 1 {
+		t.Errorf("pos 9: got %v, %v\nwant 1", c, err)
+	}
+}
+
+func TestFlattenSpansInnerSpanAtLineHead(t *testing.T) {
+	// function f() {  // line 1, count 0
+	// x = 1;          // line 2, count 1
+	// }               // line 3, count 1
+	spans := []span{
+		{startLine: 1, startCol: 1, endLine: 3, endCol: 1, numStmt: 1, count: 0},
+		{startLine: 2, startCol: 1, endLine: 3, endCol: 1, numStmt: 1, count: 1},
+	}
+	blocks := flattenSpans(spans)
+	if want := 1; *blocks[0].EndLine != want {
+		t.Errorf("got %v\nwant %v", *blocks[0].EndLine, want)
+	}
+	lcs := blocks.ToLineCoverages()
+	lc, err := lcs.FindByLine(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lc.Count != 1 || lc.Partial {
+		t.Errorf("got %v (partial %v)\nwant 1 (not partial)", lc.Count, lc.Partial)
+	}
+}
@@ -0,0 +1,90 @@
+package coverage
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FunctionCoverage is the coverage of a function (or method): where it is declared and how many times it was called.
+type FunctionCoverage struct {
+	Name      string    `json:"name"`
+	StartLine int       `json:"start_line"`
+	EndLine   int       `json:"end_line,omitempty"`
+	Count     ExecCount `json:"count"`
+}
+
+type FunctionCoverages []*FunctionCoverage
+
+func (fc FunctionCoverages) Total() int { //nostyle:recvtype
+	return len(fc)
+}
+
+func (fc FunctionCoverages) Covered() int { //nostyle:recvtype
+	c := 0
+	for _, f := range fc {
+		if f.Count > 0 {
+			c++
+		}
+	}
+	return c
+}
+
+func (fc FunctionCoverages) FindByName(name string) (*FunctionCoverage, error) { //nostyle:recvtype
+	for _, f := range fc {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("function coverage not found: %s", name)
+}
+
+// merge merges the functions of the same file from another report. The call counts of the same function are summed up.
+func (fc FunctionCoverages) merge(fc2 FunctionCoverages) FunctionCoverages { //nostyle:recvtype
+	for _, f2 := range fc2 {
+		f := fc.find(f2.Name, f2.StartLine)
+		if f == nil {
+			fc = append(fc, &FunctionCoverage{Name: f2.Name, StartLine: f2.StartLine, EndLine: f2.EndLine, Count: f2.Count})
+			continue
+		}
+		f.Count = satAdd(f.Count, f2.Count)
+		if f.EndLine == 0 {
+			f.EndLine = f2.EndLine
+		}
+	}
+	fc.sort()
+	return fc
+}
+
+func (fc FunctionCoverages) find(name string, startLine int) *FunctionCoverage { //nostyle:recvtype
+	for _, f := range fc {
+		if f.Name == name && f.StartLine == startLine {
+			return f
+		}
+	}
+	return nil
+}
+
+func (fc FunctionCoverages) sort() { //nostyle:recvtype
+	sort.SliceStable(fc, func(i, j int) bool {
+		if fc[i].StartLine != fc[j].StartLine {
+			return fc[i].StartLine < fc[j].StartLine
+		}
+		return fc[i].Name < fc[j].Name
+	})
+}
+
+// setFunctions sets the functions of the file and their totals.
+func (fc *FileCoverage) setFunctions(funcs FunctionCoverages) {
+	funcs.sort()
+	fc.Functions = funcs
+	fc.FunctionTotal = funcs.Total()
+	fc.FunctionCovered = funcs.Covered()
+}
+
+// FunctionPercent returns the function coverage of the report. It returns 0 if no functions are recorded.
+func (c *Coverage) FunctionPercent() float64 {
+	if c == nil || c.FunctionTotal == 0 {
+		return 0.0
+	}
+	return float64(c.FunctionCovered) / float64(c.FunctionTotal) * 100
+}
@@ -0,0 +1,194 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFunctions(t *testing.T) {
+	tests := []struct {
+		name        string
+		processor   Processor
+		path        string
+		wantTotal   int
+		wantCovered int
+	}{
+		{"lcov", NewLcov(), filepath.Join(testdataDir(t), "lcov_branch", "branch.lcov"), 4, 3},
+		// Same as the report level METHOD counter.
+		{"jacoco", NewJacoco(), filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), 6303, 5409},
+		{"istanbul", NewIstanbul(), filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json"), 4, 3},
+		{"llvm-cov", NewLLVMCov(), filepath.Join(testdataDir(t), "llvmcov", "coverage.json"), 2, 1},
+		{"gcov", NewGcov(), filepath.Join(testdataDir(t), "gcov"), 3, 2},
+		{"gocover", NewGocover(), filepath.Join(testdataDir(t), "gocover", "coverage.out"), 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := tt.processor.ParseReport(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.FunctionTotal != tt.wantTotal {
+				t.Errorf("got %v\nwant %v", got.FunctionTotal, tt.wantTotal)
+			}
+			if got.FunctionCovered != tt.wantCovered {
+				t.Errorf("got %v\nwant %v", got.FunctionCovered, tt.wantCovered)
+			}
+			total := 0
+			covered := 0
+			for _, f := range got.Files {
+				total += f.FunctionTotal
+				covered += f.FunctionCovered
+			}
+			if total != got.FunctionTotal {
+				t.Errorf("got %v\nwant %v", total, got.FunctionTotal)
+			}
+			if covered != got.FunctionCovered {
+				t.Errorf("got %v\nwant %v", covered, got.FunctionCovered)
+			}
+		})
+	}
+}
+
+func TestLcovFunctions(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "lcov_branch", "branch.lcov")
+	got, _, err := NewLcov().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := got.Files.FindByFile("src/calc.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := FunctionCoverages{
+		{Name: "add", StartLine: 1, Count: 3},
+		{Name: "sign", StartLine: 5, EndLine: 7, Count: 2},
+		{Name: "unused", StartLine: 9, Count: 0},
+	}
+	if diff := cmp.Diff(fc.Functions, want); diff != "" {
+		t.Error(diff)
+	}
+	fc, err = got.Files.FindByFile("src/noop.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = FunctionCoverages{
+		{Name: "ns::noop", StartLine: 1, Count: 1},
+	}
+	if diff := cmp.Diff(fc.Functions, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestGcovFunctions(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "gcov")
+	got, _, err := NewGcov().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := got.Files.FindByFile("/home/runner/work/app/app/src/util.h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// clamp is called from both object files.
+	f, err := fc.Functions.FindByName("clamp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ExecCount(3); f.Count != want {
+		t.Errorf("got %v\nwant %v", f.Count, want)
+	}
+}
+
+func TestSetGoFunctions(t *testing.T) {
+	c := &Coverage{
+		Type: TypeStmt,
+		Files: FileCoverages{
+			&FileCoverage{
+				File:           "example.com/calc/calc.go",
+				NormalizedPath: "calc.go",
+				Type:           TypeStmt,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeStmt, 5, 24, 7, 2, 1, 3),
+					newBlockCoverage(TypeStmt, 9, 31, 10, 12, 1, 2),
+					newBlockCoverage(TypeStmt, 10, 12, 12, 3, 1, 1),
+					newBlockCoverage(TypeStmt, 13, 2, 13, 10, 1, 1),
+					// Stacked by merging another profile.
+					newBlockCoverage(TypeStmt, 9, 31, 10, 12, 1, 1),
+					newBlockCoverage(TypeStmt, 16, 15, 18, 2, 1, 0),
+				},
+			},
+		},
+	}
+	c.SetGoFunctions(filepath.Join(testdataDir(t), "gofunc"))
+	if err := c.reCalc(); err != nil {
+		t.Fatal(err)
+	}
+	want := FunctionCoverages{
+		{Name: "Add", StartLine: 5, EndLine: 7, Count: 3},
+		{Name: "(*Calc).Abs", StartLine: 9, EndLine: 14, Count: 3},
+		{Name: "Unused", StartLine: 16, EndLine: 18, Count: 0},
+	}
+	if diff := cmp.Diff(c.Files[0].Functions, want); diff != "" {
+		t.Error(diff)
+	}
+	if want := 3; c.FunctionTotal != want {
+		t.Errorf("got %v\nwant %v", c.FunctionTotal, want)
+	}
+	if want := 2; c.FunctionCovered != want {
+		t.Errorf("got %v\nwant %v", c.FunctionCovered, want)
+	}
+}
+
+func TestMergeFunctions(t *testing.T) {
+	c1 := &Coverage{
+		Type: TypeLOC,
+		Files: FileCoverages{
+			&FileCoverage{
+				File: "file_a.js",
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+				},
+				Functions: FunctionCoverages{
+					{Name: "a", StartLine: 1, Count: 1},
+					{Name: "b", StartLine: 5, Count: 0},
+				},
+			},
+		},
+	}
+	c2 := &Coverage{
+		Type: TypeLOC,
+		Files: FileCoverages{
+			&FileCoverage{
+				File: "file_a.js",
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+				},
+				Functions: FunctionCoverages{
+					{Name: "a", StartLine: 1, Count: 2},
+					{Name: "c", StartLine: 9, Count: 0},
+				},
+			},
+		},
+	}
+	if err := c1.Merge(c2); err != nil {
+		t.Fatal(err)
+	}
+	want := FunctionCoverages{
+		{Name: "a", StartLine: 1, Count: 3},
+		{Name: "b", StartLine: 5, Count: 0},
+		{Name: "c", StartLine: 9, Count: 0},
+	}
+	if diff := cmp.Diff(c1.Files[0].Functions, want); diff != "" {
+		t.Error(diff)
+	}
+	if want := 3; c1.FunctionTotal != want {
+		t.Errorf("got %v\nwant %v", c1.FunctionTotal, want)
+	}
+	if want := 1; c1.FunctionCovered != want {
+		t.Errorf("got %v\nwant %v", c1.FunctionCovered, want)
+	}
+}
@@ -0,0 +1,225 @@
+package coverage
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+var _ Processor = (*Gcov)(nil)
+
+// GcovDefaultPatterns are the file name patterns searched for when a directory is given.
+var GcovDefaultPatterns = []string{"*.gcov.json.gz", "*.gcov.json"}
+
+type Gcov struct{}
+
+// GcovReport is the JSON intermediate format written by `gcov --json-format` (GCC 9+).
+type GcovReport struct {
+	FormatVersion           string           `json:"format_version"`
+	GCCVersion              string           `json:"gcc_version"`
+	CurrentWorkingDirectory string           `json:"current_working_directory"`
+	DataFile                string           `json:"data_file"`
+	Files                   []GcovReportFile `json:"files"`
+}
+
+type GcovReportFile struct {
+	File      string               `json:"file"`
+	Functions []GcovReportFunction `json:"functions"`
+	Lines     []GcovReportLine     `json:"lines"`
+}
+
+type GcovReportFunction struct {
+	Name           string    `json:"name"`
+	DemangledName  string    `json:"demangled_name"`
+	StartLine      int       `json:"start_line"`
+	StartColumn    int       `json:"start_column"`
+	EndLine        int       `json:"end_line"`
+	EndColumn      int       `json:"end_column"`
+	Blocks         int       `json:"blocks"`
+	BlocksExecuted int       `json:"blocks_executed"`
+	ExecutionCount ExecCount `json:"execution_count"`
+}
+
+type GcovReportLine struct {
+	LineNumber      int                `json:"line_number"`
+	Count           ExecCount          `json:"count"`
+	UnexecutedBlock bool               `json:"unexecuted_block"`
+	FunctionName    string             `json:"function_name"`
+	Branches        []GcovReportBranch `json:"branches"`
+}
+
+type GcovReportBranch struct {
+	Count       ExecCount `json:"count"`
+	Fallthrough bool      `json:"fallthrough"`
+	Throw       bool      `json:"throw"`
+}
+
+func NewGcov() *Gcov {
+	return &Gcov{}
+}
+
+func (g *Gcov) Name() string {
+	return "gcov"
+}
+
+// ParseReport parses a gcov JSON file or all gcov JSON files in a directory.
+// gcov writes one file per object file, so a source file such as a header may appear
+// in several of them; its line counts are summed.
+func (g *Gcov) ParseReport(path string) (*Coverage, string, error) {
+	rp, files, err := g.detectReportPaths(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	lines := map[string]map[int]ExecCount{}
+	branches := map[string]map[int][]ExecCount{}
+	funcs := map[string]FunctionCoverages{}
+	for _, f := range files {
+		r, err := g.readReport(f)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, rf := range r.Files {
+			n := rf.File
+			if !filepath.IsAbs(n) && r.CurrentWorkingDirectory != "" {
+				n = filepath.Join(r.CurrentWorkingDirectory, n)
+			}
+			lcs, ok := lines[n]
+			if !ok {
+				lcs = map[int]ExecCount{}
+				lines[n] = lcs
+			}
+			var fcs FunctionCoverages
+			for _, fn := range rf.Functions {
+				name := fn.DemangledName
+				if name == "" {
+					name = fn.Name
+				}
+				fcs = append(fcs, &FunctionCoverage{Name: name, StartLine: fn.StartLine, EndLine: fn.EndLine, Count: fn.ExecutionCount})
+			}
+			funcs[n] = funcs[n].merge(fcs)
+			bcs, ok := branches[n]
+			if !ok {
+				bcs = map[int][]ExecCount{}
+				branches[n] = bcs
+			}
+			for _, l := range rf.Lines {
+				lcs[l.LineNumber] = satAdd(lcs[l.LineNumber], l.Count)
+				// The branches of the same line in several object files are the same ones.
+				for i, b := range l.Branches {
+					if i < len(bcs[l.LineNumber]) {
+						bcs[l.LineNumber][i] = satAdd(bcs[l.LineNumber][i], b.Count)
+						continue
+					}
+					bcs[l.LineNumber] = append(bcs[l.LineNumber], b.Count)
+				}
+			}
+		}
+	}
+
+	cov := New()
+	cov.Type = TypeLOC
+	cov.Format = g.Name()
+
+	names := make([]string, 0, len(lines))
+	for n := range lines {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fcov := NewFileCoverage(n, TypeLOC)
+		lns := make([]int, 0, len(lines[n]))
+		for l := range lines[n] {
+			lns = append(lns, l)
+		}
+		sort.Ints(lns)
+		for _, l := range lns {
+			sl := l
+			el := l
+			c := lines[n][l]
+			fcov.Blocks = append(fcov.Blocks, &BlockCoverage{
+				Type:      TypeLOC,
+				StartLine: &sl,
+				EndLine:   &el,
+				Count:     &c,
+			})
+			fcov.Total++
+			if c > 0 {
+				fcov.Covered++
+			}
+		}
+		var bc BranchCoverages
+		for l, counts := range branches[n] {
+			taken := make([]bool, len(counts))
+			for i, c := range counts {
+				taken[i] = c > 0
+			}
+			bc = bc.addTaken(l, taken...)
+		}
+		fcov.setBranches(bc)
+		fcov.setFunctions(funcs[n])
+		cov.Total += fcov.Total
+		cov.Covered += fcov.Covered
+		cov.BranchTotal += fcov.BranchTotal
+		cov.BranchCovered += fcov.BranchCovered
+		cov.FunctionTotal += fcov.FunctionTotal
+		cov.FunctionCovered += fcov.FunctionCovered
+		cov.Files = append(cov.Files, fcov)
+	}
+
+	return cov, rp, nil
+}
+
+func (g *Gcov) readReport(path string) (*GcovReport, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var rd io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		rd = zr
+	}
+	r := &GcovReport{}
+	if err := json.NewDecoder(rd).Decode(r); err != nil {
+		return nil, err
+	}
+	if r.FormatVersion == "" || r.Files == nil {
+		return nil, fmt.Errorf("%s is not gcov JSON format", filepath.Clean(path))
+	}
+	return r, nil
+}
+
+func (g *Gcov) detectReportPaths(path string) (string, []string, error) {
+	p, err := os.Stat(path)
+	if err != nil {
+		return "", nil, err
+	}
+	if !p.IsDir() {
+		return path, []string{path}, nil
+	}
+	var files []string
+	for _, pattern := range GcovDefaultPatterns {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return "", nil, err
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return "", nil, fmt.Errorf("gcov JSON files not found: %s", filepath.Clean(path))
+	}
+	sort.Strings(files)
+	return path, files, nil
+}
@@ -0,0 +1,110 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGcov(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "gcov")
+	got, _, err := NewGcov().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 10; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 7; got.Covered != want {
+		t.Errorf("got %v\nwant %v", got.Covered, want)
+	}
+	if want := 3; len(got.Files) != want {
+		t.Fatalf("got %v\nwant %v", len(got.Files), want)
+	}
+	// Relative paths are resolved against current_working_directory.
+	if want := "/home/runner/work/app/app/src/main.c"; got.Files[0].File != want {
+		t.Errorf("got %v\nwant %v", got.Files[0].File, want)
+	}
+
+	for _, f := range got.Files {
+		lcs := f.Blocks.ToLineCoverages()
+		if got := f.Total; got != lcs.Total() {
+			t.Errorf("got %v\nwant %v", got, lcs.Total())
+		}
+		if got := f.Covered; got != lcs.Covered() {
+			t.Errorf("got %v\nwant %v", got, lcs.Covered())
+		}
+	}
+}
+
+func TestGcovMergeSharedFile(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "gcov")
+	got, _, err := NewGcov().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc, err := got.Files.FindByFile("/home/runner/work/app/app/src/util.h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lcs := fc.Blocks.ToLineCoverages()
+	tests := []struct {
+		line int
+		want ExecCount
+	}{
+		{4, 3},
+		{5, 2},
+		{6, 1},
+	}
+	for _, tt := range tests {
+		lc, err := lcs.FindByLine(tt.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lc.Count != tt.want {
+			t.Errorf("line %d: got %v\nwant %v", tt.line, lc.Count, tt.want)
+		}
+	}
+}
+
+func TestGcovSingleFile(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "gcov", "util.gcov.json.gz")
+	got, rp, err := NewGcov().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp != path {
+		t.Errorf("got %v\nwant %v", rp, path)
+	}
+	if want := 5; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 2; got.Covered != want {
+		t.Errorf("got %v\nwant %v", got.Covered, want)
+	}
+}
+
+func TestGcovParseAllFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{filepath.Join(testdataDir(t), "gocover", "coverage.out"), true},
+		{filepath.Join(testdataDir(t), "lcov", "lcov.info"), true},
+		{filepath.Join(testdataDir(t), "simplecov", ".resultset.json"), true},
+		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "cobertura", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), true},
+		{filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json"), true},
+		{filepath.Join(testdataDir(t), "coveragepy", "coverage.json"), true},
+		{filepath.Join(testdataDir(t), "llvmcov", "coverage.json"), true},
+		{filepath.Join(testdataDir(t), "opencover", "coverage.opencover.xml"), true},
+		{filepath.Join(testdataDir(t), "gcov", "main.gcov.json.gz"), false},
+		{filepath.Join(testdataDir(t), "sonar", "coverage.xml"), true},
+	}
+	for _, tt := range tests {
+		_, _, err := NewGcov().ParseReport(tt.path)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+		}
+	}
+}
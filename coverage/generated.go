@@ -0,0 +1,191 @@
+package coverage
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	GeneratedReasonHeader     = "Code generated header"
+	GeneratedReasonMarker     = "@generated marker"
+	GeneratedReasonAttributes = "linguist-generated in .gitattributes"
+
+	// generatedHeaderMaxLines is the number of lines at the head of a source file searched for the generated header.
+	generatedHeaderMaxLines = 100
+)
+
+var (
+	generatedHeaderRe = regexp.MustCompile(`^\s*(?://|#|/\*+|\*|--|;)\s*Code generated .* DO NOT EDIT\.?`)
+	generatedMarkerRe = regexp.MustCompile(`^\s*(?://|#|/\*+|\*|--|;)\s*@generated(?:\s|\*/|$)`)
+)
+
+// GeneratedFile is a file excluded from the coverage as generated source.
+type GeneratedFile struct {
+	File   string
+	Reason string
+}
+
+// ExcludeGenerated excludes the normalized files detected as generated sources and returns them.
+// Files are detected by the `Code generated ... DO NOT EDIT.` header, the `@generated` marker
+// and the `linguist-generated` attribute in .gitattributes files.
+// Total and Covered are updated by reCalc.
+func (c *Coverage) ExcludeGenerated(root string) ([]*GeneratedFile, error) {
+	if c == nil || root == "" {
+		return nil, nil
+	}
+	attrs := newGitAttributes(root)
+	var (
+		files     FileCoverages
+		generated []*GeneratedFile
+	)
+	for _, f := range c.Files {
+		reason, err := detectGenerated(root, f.NormalizedPath, attrs)
+		if err != nil {
+			return nil, err
+		}
+		if reason == "" {
+			files = append(files, f)
+			continue
+		}
+		generated = append(generated, &GeneratedFile{
+			File:   f.EffectivePath(),
+			Reason: reason,
+		})
+	}
+	c.Files = files
+	return generated, nil
+}
+
+// detectGenerated returns the reason why the file is detected as generated source, or "" if it is not.
+func detectGenerated(root, rel string, attrs *gitAttributes) (string, error) {
+	if rel == "" || filepath.IsAbs(rel) {
+		return "", nil
+	}
+	generated, err := attrs.generated(rel)
+	if err != nil {
+		return "", err
+	}
+	if generated {
+		return GeneratedReasonAttributes, nil
+	}
+	f, err := os.Open(filepath.Clean(filepath.Join(root, rel)))
+	if err != nil {
+		return "", nil //nolint:nilerr // files not found on the filesystem are not detected
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for n := 0; n < generatedHeaderMaxLines && scanner.Scan(); n++ {
+		line := scanner.Text()
+		if generatedHeaderRe.MatchString(line) {
+			return GeneratedReasonHeader, nil
+		}
+		if generatedMarkerRe.MatchString(line) {
+			return GeneratedReasonMarker, nil
+		}
+	}
+	// The scan stops at a too long line (e.g. minified files), which is not a generated header.
+	return "", nil
+}
+
+// gitAttributes reads the `linguist-generated` attribute from the .gitattributes files under root.
+type gitAttributes struct {
+	root  string
+	rules map[string][]gitAttributeRule
+}
+
+type gitAttributeRule struct {
+	pattern   string
+	generated bool
+}
+
+func newGitAttributes(root string) *gitAttributes {
+	return &gitAttributes{
+		root:  root,
+		rules: map[string][]gitAttributeRule{},
+	}
+}
+
+// generated reports whether the file is marked as `linguist-generated`.
+// The .gitattributes files are read from root to the directory of the file, and the last matching rule wins.
+func (a *gitAttributes) generated(rel string) (bool, error) {
+	rel = filepath.ToSlash(rel)
+	generated := false
+	for _, d := range parentDirs(rel) {
+		rules, err := a.load(d)
+		if err != nil {
+			return false, err
+		}
+		p := rel
+		if d != "." {
+			p = strings.TrimPrefix(rel, d+"/")
+		}
+		for _, r := range rules {
+			if matchGitAttributePattern(r.pattern, p) {
+				generated = r.generated
+			}
+		}
+	}
+	return generated, nil
+}
+
+func (a *gitAttributes) load(dir string) ([]gitAttributeRule, error) {
+	if rules, ok := a.rules[dir]; ok {
+		return rules, nil
+	}
+	var rules []gitAttributeRule
+	b, err := os.ReadFile(filepath.Join(a.root, filepath.FromSlash(dir), ".gitattributes"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for line := range strings.Lines(string(b)) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			switch attr {
+			case "linguist-generated", "linguist-generated=true":
+				rules = append(rules, gitAttributeRule{pattern: fields[0], generated: true})
+			case "-linguist-generated", "!linguist-generated", "linguist-generated=false":
+				rules = append(rules, gitAttributeRule{pattern: fields[0], generated: false})
+			}
+		}
+	}
+	a.rules[dir] = rules
+	return rules, nil
+}
+
+// matchGitAttributePattern reports whether the pattern in a .gitattributes file matches the path relative to the file.
+// A pattern without a slash matches the base name at any level.
+func matchGitAttributePattern(pattern, p string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		// Patterns of directories do not match files.
+		return false
+	}
+	if !strings.Contains(pattern, "/") {
+		match, err := doublestar.Match(pattern, path.Base(p))
+		return err == nil && match
+	}
+	match, err := doublestar.Match(strings.TrimPrefix(pattern, "/"), p)
+	return err == nil && match
+}
+
+// parentDirs returns the directories from root to the directory of the file.
+func parentDirs(rel string) []string {
+	var dirs []string
+	for d := path.Dir(rel); d != "."; d = path.Dir(d) {
+		dirs = append(dirs, d)
+	}
+	dirs = append(dirs, ".")
+	slices.Reverse(dirs)
+	return dirs
+}
@@ -0,0 +1,64 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExcludeGenerated(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".gitattributes":         "*.gen.ts linguist-generated\ndb/** linguist-generated=true\ndb/keep.go -linguist-generated\n",
+		"api/api.pb.go":          "// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: api.proto\n\npackage api\n",
+		"api/api.go":             "package api\n\n// Code generated by hand, please edit.\n",
+		"mock/mock.go":           "// Copyright 2024\n\n/**\n * @generated SignedSource<<abc>>\n */\npackage mock\n",
+		"web/client.gen.ts":      "export const a = 1;\n",
+		"web/app.ts":             "// see @generated files\nexport const b = 1;\n",
+		"db/query.sql.go":        "package db\n",
+		"db/keep.go":             "package db\n",
+		"lib/.gitattributes":     "util.go linguist-generated\n",
+		"lib/util.go":            "package lib\n",
+		"lib/internal/util.go":   "package internal\n",
+		"lib/internal/helper.go": "package internal\n",
+	}
+	for p, content := range files {
+		fp := filepath.Join(root, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fp, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &Coverage{}
+	for _, p := range []string{"api/api.pb.go", "api/api.go", "mock/mock.go", "web/client.gen.ts", "web/app.ts", "db/query.sql.go", "db/keep.go", "lib/util.go", "lib/internal/util.go", "lib/internal/helper.go", "notfound/file.go"} {
+		c.Files = append(c.Files, &FileCoverage{File: "github.com/owner/repo/" + p, NormalizedPath: p})
+	}
+	c.Files = append(c.Files, &FileCoverage{File: "not/normalized.go"})
+
+	got, err := c.ExcludeGenerated(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*GeneratedFile{
+		{File: "api/api.pb.go", Reason: GeneratedReasonHeader},
+		{File: "mock/mock.go", Reason: GeneratedReasonMarker},
+		{File: "web/client.gen.ts", Reason: GeneratedReasonAttributes},
+		{File: "db/query.sql.go", Reason: GeneratedReasonAttributes},
+		{File: "lib/util.go", Reason: GeneratedReasonAttributes},
+		{File: "lib/internal/util.go", Reason: GeneratedReasonAttributes},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+	var remain []string
+	for _, f := range c.Files {
+		remain = append(remain, f.EffectivePath())
+	}
+	if diff := cmp.Diff([]string{"api/api.go", "web/app.ts", "db/keep.go", "lib/internal/helper.go", "notfound/file.go", "not/normalized.go"}, remain); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -26,6 +26,13 @@ func (g *Gocover) ParseReport(path string) (*Coverage, string, error) {
 	if err != nil {
 		return nil, "", err
 	}
+	if fi, err := os.Stat(rp); err == nil && fi.IsDir() {
+		cov, err := g.parseGoCoverDir(rp)
+		if err != nil {
+			return nil, "", err
+		}
+		return cov, rp, nil
+	}
 	profiles, err := cover.ParseProfiles(rp)
 	if err != nil {
 		return nil, "", err
@@ -68,10 +75,15 @@ func (g *Gocover) detectReportPath(path string) (string, error) {
 		return "", err
 	}
 	if p.IsDir() {
-		path = filepath.Join(path, GocoverDefaultPath)
-	}
-	if _, err := os.Stat(path); err != nil {
-		return "", err
+		np := filepath.Join(path, GocoverDefaultPath)
+		if _, err := os.Stat(np); err != nil {
+			// GOCOVERDIR
+			if isGoCoverDir(path) {
+				return path, nil
+			}
+			return "", err
+		}
+		return np, nil
 	}
 	return path, nil
 }
@@ -1,9 +1,12 @@
 package coverage
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestGocover(t *testing.T) {
@@ -47,6 +50,7 @@ func TestGocoverParseAllFormat(t *testing.T) {
 		wantErr bool
 	}{
 		{filepath.Join(testdataDir(t), "gocover", "coverage.out"), false},
+		{filepath.Join(testdataDir(t), "gocoverdir"), false},
 		{filepath.Join(testdataDir(t), "lcov", "lcov.info"), true},
 		{filepath.Join(testdataDir(t), "simplecov", ".resultset.json"), true},
 		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), true},
@@ -61,6 +65,83 @@ func TestGocoverParseAllFormat(t *testing.T) {
 	}
 }
 
+func TestGocoverDir(t *testing.T) {
+	// testdata/gocoverdir is GOCOVERDIR of two runs of a program built with `go build -cover -covermode=count`.
+	path := filepath.Join(testdataDir(t), "gocoverdir")
+	got, rp, err := NewGocover().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp != path {
+		t.Errorf("got %v\nwant %v", rp, path)
+	}
+	if want := 7; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 5; got.Covered != want {
+		t.Errorf("got %v\nwant %v", got.Covered, want)
+	}
+
+	// Same as `go tool covdata textfmt -i=testdata/gocoverdir`.
+	want := map[string][]string{
+		"example.com/app/calc/calc.go": {
+			"4.2,4.11 1 2",
+			"5.3,6.1 1 2",
+			"7.2,7.10 1 0",
+			"11.2,12.1 1 0",
+		},
+		"example.com/app/main.go": {
+			"11.2,11.22 1 2",
+			"12.3,13.1 1 1",
+			"14.2,14.27 1 2",
+		},
+	}
+	if len(got.Files) != len(want) {
+		t.Fatalf("got %v\nwant %v", len(got.Files), len(want))
+	}
+	for _, f := range got.Files {
+		var blocks []string
+		for _, b := range f.Blocks {
+			blocks = append(blocks, fmt.Sprintf("%d.%d,%d.%d %d %d", *b.StartLine, *b.StartCol, *b.EndLine, *b.EndCol, *b.NumStmt, *b.Count))
+		}
+		if diff := cmp.Diff(blocks, want[f.File]); diff != "" {
+			t.Errorf("%s: %s", f.File, diff)
+		}
+	}
+}
+
+func TestGocoverDirMerge(t *testing.T) {
+	unit := filepath.Join(t.TempDir(), GocoverDefaultPath)
+	profile := `mode: count
+example.com/app/calc/calc.go:4.2,4.11 1 1
+example.com/app/calc/calc.go:5.3,6.1 1 0
+example.com/app/calc/calc.go:7.2,7.10 1 1
+example.com/app/calc/calc.go:11.2,12.1 1 1
+`
+	if err := os.WriteFile(unit, []byte(profile), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := NewGocover().ParseReport(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	integration, _, err := NewGocover().ParseReport(filepath.Join(testdataDir(t), "gocoverdir"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.Merge(integration); err != nil {
+		t.Fatal(err)
+	}
+	fc, err := got.Files.FindByFile("example.com/app/calc/calc.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every line is covered by either the unit tests or the integration run.
+	if fc.Covered != fc.Total {
+		t.Errorf("got %v\nwant %v", fc.Covered, fc.Total)
+	}
+}
+
 func testdataDir(t *testing.T) string {
 	t.Helper()
 	wd, err := os.Getwd()
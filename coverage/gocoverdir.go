@@ -0,0 +1,470 @@
+package coverage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Binary coverage data files written into GOCOVERDIR by programs built with `go build -cover` (Go 1.20+).
+// The layout follows internal/coverage of the Go distribution.
+
+const (
+	goCoverMetaFilePrefix    = "covmeta."
+	goCoverCounterFilePrefix = "covcounters."
+
+	goCoverMetaFileHeaderSize    = 56
+	goCoverMetaSymbolHeaderSize  = 44
+	goCoverCounterFileHeaderSize = 32
+	goCoverCounterFileFooterSize = 16
+)
+
+var (
+	goCoverMetaMagic    = [4]byte{0x00, 0x63, 0x76, 0x6d}
+	goCoverCounterMagic = [4]byte{0x00, 0x63, 0x77, 0x6d}
+)
+
+const (
+	goCoverCtrModeSet      = 1
+	goCoverCtrGranPerFunc  = 2
+	goCoverCtrFlavorRaw    = 1
+	goCoverCtrFlavorULeb   = 2
+	goCoverFileMaxVersion  = 1
+	goCoverMaxULEB128Shift = 63
+)
+
+type goCoverMetaFile struct {
+	mode    uint8
+	perFunc bool
+	pkgs    []goCoverMetaPackage
+}
+
+type goCoverMetaPackage struct {
+	funcs []goCoverMetaFunc
+}
+
+type goCoverMetaFunc struct {
+	file  string
+	units []goCoverMetaUnit
+}
+
+type goCoverMetaUnit struct {
+	startLine, startCol, endLine, endCol, numStmt int
+}
+
+type goCoverUnitKey struct {
+	file string
+	goCoverMetaUnit
+}
+
+// isGoCoverDir reports whether dir contains coverage meta-data files written into GOCOVERDIR.
+func isGoCoverDir(dir string) bool {
+	matches, err := filepath.Glob(filepath.Join(dir, goCoverMetaFilePrefix+"*"))
+	return err == nil && len(matches) > 0
+}
+
+// parseGoCoverDir decodes the meta-data and counter data files in dir in the same way as `go tool covdata textfmt`.
+func (g *Gocover) parseGoCoverDir(dir string) (*Coverage, error) {
+	metaPaths, err := filepath.Glob(filepath.Join(dir, goCoverMetaFilePrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	counterPaths, err := filepath.Glob(filepath.Join(dir, goCoverCounterFilePrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(metaPaths)
+	sort.Strings(counterPaths)
+
+	metas := map[[16]byte]*goCoverMetaFile{}
+	counts := map[goCoverUnitKey]ExecCount{}
+	set := false
+	for _, p := range metaPaths {
+		b, err := os.ReadFile(filepath.Clean(p))
+		if err != nil {
+			return nil, err
+		}
+		hash, mf, err := decodeGoCoverMetaFile(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Clean(p), err)
+		}
+		metas[hash] = mf
+		if mf.mode == goCoverCtrModeSet {
+			set = true
+		}
+		// Units of functions that were never executed have no counters.
+		for _, pkg := range mf.pkgs {
+			for _, fn := range pkg.funcs {
+				for _, u := range fn.units {
+					counts[goCoverUnitKey{file: fn.file, goCoverMetaUnit: u}] += 0
+				}
+			}
+		}
+	}
+
+	for _, p := range counterPaths {
+		b, err := os.ReadFile(filepath.Clean(p))
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeGoCoverCounterFile(b, metas, counts); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Clean(p), err)
+		}
+	}
+
+	files := map[string][]goCoverMetaUnit{}
+	for k := range counts {
+		files[k.file] = append(files[k.file], k.goCoverMetaUnit)
+	}
+	names := make([]string, 0, len(files))
+	for n := range files {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	cov := New()
+	cov.Type = TypeStmt
+	cov.Format = g.Name()
+	for _, n := range names {
+		units := files[n]
+		sort.Slice(units, func(i, j int) bool {
+			if units[i].startLine != units[j].startLine {
+				return units[i].startLine < units[j].startLine
+			}
+			return units[i].startCol < units[j].startCol
+		})
+		fcov := NewFileCoverage(n, TypeStmt)
+		for _, u := range units {
+			sl := u.startLine
+			sc := u.startCol
+			el := u.endLine
+			ec := u.endCol
+			ns := u.numStmt
+			c := counts[goCoverUnitKey{file: n, goCoverMetaUnit: u}]
+			if set && c > 0 {
+				c = 1
+			}
+			fcov.Blocks = append(fcov.Blocks, &BlockCoverage{
+				Type:      TypeStmt,
+				StartLine: &sl,
+				StartCol:  &sc,
+				EndLine:   &el,
+				EndCol:    &ec,
+				NumStmt:   &ns,
+				Count:     &c,
+			})
+			fcov.Total += ns
+			if c > 0 {
+				fcov.Covered += ns
+			}
+		}
+		cov.Total += fcov.Total
+		cov.Covered += fcov.Covered
+		cov.Files = append(cov.Files, fcov)
+	}
+	return cov, nil
+}
+
+func decodeGoCoverMetaFile(b []byte) ([16]byte, *goCoverMetaFile, error) {
+	var hash [16]byte
+	r := &goCoverReader{b: b}
+	var magic [4]byte
+	copy(magic[:], r.bytes(4))
+	if magic != goCoverMetaMagic {
+		return hash, nil, errors.New("invalid magic string: not a coverage meta-data file")
+	}
+	if v := r.uint32(); v > goCoverFileMaxVersion {
+		return hash, nil, fmt.Errorf("unsupported meta-data file version: %d", v)
+	}
+	_ = r.uint64() // total length
+	entries := r.uint64()
+	copy(hash[:], r.bytes(16))
+	_ = r.uint32() // string table offset
+	_ = r.uint32() // string table length
+	mf := &goCoverMetaFile{
+		mode:    r.uint8(),
+		perFunc: r.uint8() == goCoverCtrGranPerFunc,
+	}
+	r.seek(goCoverMetaFileHeaderSize)
+	if r.err != nil {
+		return hash, nil, r.err
+	}
+	if entries > uint64(len(b)) {
+		return hash, nil, errors.New("malformed meta-data file header")
+	}
+	offsets := make([]uint64, entries)
+	for i := range offsets {
+		offsets[i] = r.uint64()
+	}
+	lengths := make([]uint64, entries)
+	for i := range lengths {
+		lengths[i] = r.uint64()
+	}
+	if r.err != nil {
+		return hash, nil, r.err
+	}
+	for i := range offsets {
+		if offsets[i] > uint64(len(b)) || lengths[i] > uint64(len(b))-offsets[i] {
+			return hash, nil, errors.New("malformed package offset")
+		}
+		pkg, err := decodeGoCoverMetaPackage(b[offsets[i] : offsets[i]+lengths[i]])
+		if err != nil {
+			return hash, nil, err
+		}
+		mf.pkgs = append(mf.pkgs, pkg)
+	}
+	return hash, mf, nil
+}
+
+func decodeGoCoverMetaPackage(b []byte) (goCoverMetaPackage, error) {
+	r := &goCoverReader{b: b}
+	pkg := goCoverMetaPackage{}
+	// Skip to NumFuncs, the last field of the header.
+	r.seek(goCoverMetaSymbolHeaderSize - 4)
+	numFuncs := r.uint32()
+	if r.err != nil {
+		return pkg, r.err
+	}
+	if uint64(numFuncs)*4 > uint64(len(b)) {
+		return pkg, errors.New("malformed package meta-data")
+	}
+	offsets := make([]uint32, numFuncs)
+	for i := range offsets {
+		offsets[i] = r.uint32()
+	}
+	strs := r.stringTable()
+	if r.err != nil {
+		return pkg, r.err
+	}
+	str := func(i uint64) string {
+		if i >= uint64(len(strs)) {
+			r.fail()
+			return ""
+		}
+		return strs[i]
+	}
+	for _, off := range offsets {
+		r.seek(int(off))
+		numUnits := r.uleb128()
+		_ = r.uleb128() // function name
+		fn := goCoverMetaFunc{file: str(r.uleb128())}
+		if numUnits > uint64(len(b)) {
+			return pkg, errors.New("malformed function meta-data")
+		}
+		for j := uint64(0); j < numUnits; j++ {
+			fn.units = append(fn.units, goCoverMetaUnit{
+				startLine: int(r.uleb128()),
+				startCol:  int(r.uleb128()),
+				endLine:   int(r.uleb128()),
+				endCol:    int(r.uleb128()),
+				numStmt:   int(r.uleb128()),
+			})
+		}
+		_ = r.uleb128() // function literal
+		if r.err != nil {
+			return pkg, r.err
+		}
+		pkg.funcs = append(pkg.funcs, fn)
+	}
+	return pkg, nil
+}
+
+func decodeGoCoverCounterFile(b []byte, metas map[[16]byte]*goCoverMetaFile, counts map[goCoverUnitKey]ExecCount) error {
+	r := &goCoverReader{b: b}
+	var magic [4]byte
+	copy(magic[:], r.bytes(4))
+	if magic != goCoverCounterMagic {
+		return errors.New("invalid magic string: not a coverage counter data file")
+	}
+	if v := r.uint32(); v > goCoverFileMaxVersion {
+		return fmt.Errorf("unsupported counter data file version: %d", v)
+	}
+	var hash [16]byte
+	copy(hash[:], r.bytes(16))
+	flavor := r.uint8()
+	bigEndian := r.uint8() != 0
+	if r.err != nil {
+		return r.err
+	}
+	mf, ok := metas[hash]
+	if !ok {
+		return fmt.Errorf("meta-data file not found: %s%x", goCoverMetaFilePrefix, hash)
+	}
+	rdu32 := r.uleb128
+	switch flavor {
+	case goCoverCtrFlavorULeb:
+	case goCoverCtrFlavorRaw:
+		rdu32 = func() uint64 {
+			v := r.bytes(4)
+			if v == nil {
+				return 0
+			}
+			if bigEndian {
+				return uint64(binary.BigEndian.Uint32(v))
+			}
+			return uint64(binary.LittleEndian.Uint32(v))
+		}
+	default:
+		return fmt.Errorf("unsupported counter flavor: %d", flavor)
+	}
+
+	// The footer at the end of the file holds the number of segments.
+	if len(b) < goCoverCounterFileHeaderSize+goCoverCounterFileFooterSize {
+		return errors.New("malformed counter data file")
+	}
+	fr := &goCoverReader{b: b[len(b)-goCoverCounterFileFooterSize:]}
+	copy(magic[:], fr.bytes(4))
+	_ = fr.uint32()
+	numSegments := fr.uint32()
+	if magic != goCoverCounterMagic || numSegments == 0 {
+		return errors.New("invalid counter data file footer")
+	}
+
+	r.seek(goCoverCounterFileHeaderSize)
+	for s := uint32(0); s < numSegments; s++ {
+		fcnEntries := r.uint64()
+		strTabLen := r.uint32()
+		argsLen := r.uint32()
+		r.seek(r.off + int(strTabLen) + int(argsLen))
+		if rem := r.off % 4; rem != 0 {
+			r.seek(r.off + 4 - rem)
+		}
+		if r.err != nil {
+			return r.err
+		}
+		for i := uint64(0); i < fcnEntries; i++ {
+			nc := rdu32()
+			for nc == 0 && r.err == nil {
+				nc = rdu32()
+			}
+			pkgIdx := rdu32()
+			funcIdx := rdu32()
+			if r.err != nil {
+				return r.err
+			}
+			if pkgIdx >= uint64(len(mf.pkgs)) || funcIdx >= uint64(len(mf.pkgs[pkgIdx].funcs)) {
+				return fmt.Errorf("counter data refers to unknown function: pkg %d func %d", pkgIdx, funcIdx)
+			}
+			fn := mf.pkgs[pkgIdx].funcs[funcIdx]
+			if nc > uint64(len(b)) {
+				return errors.New("malformed counter data")
+			}
+			counters := make([]ExecCount, nc)
+			for j := range counters {
+				counters[j] = ExecCount(rdu32())
+			}
+			if r.err != nil {
+				return r.err
+			}
+			for j, u := range fn.units {
+				var c ExecCount
+				switch {
+				case mf.perFunc && len(counters) > 0:
+					c = counters[0]
+				case j < len(counters):
+					c = counters[j]
+				}
+				k := goCoverUnitKey{file: fn.file, goCoverMetaUnit: u}
+				counts[k] = satAdd(counts[k], c)
+			}
+		}
+		// Each segment is followed by a footer.
+		r.seek(r.off + goCoverCounterFileFooterSize)
+	}
+	return r.err
+}
+
+// goCoverReader reads little-endian values and ULEB128 integers from a byte slice.
+// The first out-of-range read sets err and makes all subsequent reads return zero values.
+type goCoverReader struct {
+	b   []byte
+	off int
+	err error
+}
+
+func (r *goCoverReader) fail() {
+	if r.err == nil {
+		r.err = errors.New("unexpected end of coverage data")
+	}
+}
+
+func (r *goCoverReader) seek(off int) {
+	if off < 0 || off > len(r.b) {
+		r.fail()
+		return
+	}
+	r.off = off
+}
+
+func (r *goCoverReader) bytes(n int) []byte {
+	if r.err != nil || n < 0 || r.off+n > len(r.b) {
+		r.fail()
+		return nil
+	}
+	v := r.b[r.off : r.off+n]
+	r.off += n
+	return v
+}
+
+func (r *goCoverReader) uint8() uint8 {
+	v := r.bytes(1)
+	if v == nil {
+		return 0
+	}
+	return v[0]
+}
+
+func (r *goCoverReader) uint32() uint32 {
+	v := r.bytes(4)
+	if v == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(v)
+}
+
+func (r *goCoverReader) uint64() uint64 {
+	v := r.bytes(8)
+	if v == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(v)
+}
+
+func (r *goCoverReader) uleb128() uint64 {
+	var v uint64
+	for shift := uint(0); ; shift += 7 {
+		b := r.bytes(1)
+		if b == nil {
+			return 0
+		}
+		if shift > goCoverMaxULEB128Shift {
+			r.fail()
+			return 0
+		}
+		v |= uint64(b[0]&0x7f) << shift
+		if b[0]&0x80 == 0 {
+			return v
+		}
+	}
+}
+
+func (r *goCoverReader) stringTable() []string {
+	n := r.uleb128()
+	if n > uint64(len(r.b)) {
+		r.fail()
+		return nil
+	}
+	strs := make([]string, 0, n)
+	for i := uint64(0); i < n; i++ {
+		l := r.uleb128()
+		if l > uint64(len(r.b)) {
+			r.fail()
+			return nil
+		}
+		strs = append(strs, string(r.bytes(int(l))))
+	}
+	return strs
+}
@@ -0,0 +1,138 @@
+package coverage
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// SetGoFunctions maps the blocks of Go source files onto the function declarations in them.
+// Go coverage profiles do not record functions, so the source files are parsed with go/ast.
+// Files that already have functions or whose source cannot be read under root are skipped.
+func (c *Coverage) SetGoFunctions(root string) {
+	if c == nil {
+		return
+	}
+	for _, f := range c.Files {
+		if len(f.Functions) > 0 || !strings.HasSuffix(f.File, ".go") {
+			continue
+		}
+		p := f.EffectivePath()
+		if !filepath.IsAbs(p) {
+			if root == "" {
+				continue
+			}
+			p = filepath.Join(root, p)
+		}
+		funcs, err := goFunctions(p, f.Blocks)
+		if err != nil {
+			continue
+		}
+		f.setFunctions(funcs)
+	}
+}
+
+// goFunctions returns the functions declared in the Go source file.
+// The call count of a function is the count of the block at the head of its body.
+func goFunctions(path string, blocks BlockCoverages) (FunctionCoverages, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Clean(path), nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	var funcs FunctionCoverages
+	for _, d := range file.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		lbrace := fset.Position(fn.Body.Lbrace)
+		rbrace := fset.Position(fn.Body.Rbrace)
+		var (
+			head  *BlockCoverage
+			count ExecCount
+		)
+		for _, b := range blocks {
+			sl, sc := blockStart(b)
+			if !posInRange(sl, sc, lbrace, rbrace) {
+				continue
+			}
+			if head != nil {
+				hl, hc := blockStart(head)
+				if hl < sl || (hl == sl && hc < sc) {
+					continue
+				}
+				if hl == sl && hc == sc {
+					// The same block stacked by merging reports.
+					count = satAdd(count, *b.Count)
+					continue
+				}
+			}
+			head = b
+			count = *b.Count
+		}
+		if head == nil {
+			// Not instrumented.
+			continue
+		}
+		funcs = append(funcs, &FunctionCoverage{
+			Name:      goFuncName(fn),
+			StartLine: fset.Position(fn.Pos()).Line,
+			EndLine:   fset.Position(fn.End()).Line,
+			Count:     count,
+		})
+	}
+	return funcs, nil
+}
+
+// goFuncName returns the name of the function qualified by its receiver type (e.g. `(*T).Name`).
+func goFuncName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	t := fn.Recv.List[0].Type
+	ptr := false
+	if s, ok := t.(*ast.StarExpr); ok {
+		ptr = true
+		t = s.X
+	}
+	// Type parameters of a generic receiver are dropped.
+	switch tt := t.(type) {
+	case *ast.IndexExpr:
+		t = tt.X
+	case *ast.IndexListExpr:
+		t = tt.X
+	}
+	name := "?"
+	if id, ok := t.(*ast.Ident); ok {
+		name = id.Name
+	}
+	if ptr {
+		return fmt.Sprintf("(*%s).%s", name, fn.Name.Name)
+	}
+	return fmt.Sprintf("%s.%s", name, fn.Name.Name)
+}
+
+func blockStart(b *BlockCoverage) (int, int) {
+	sc := 0
+	if b.StartCol != nil {
+		sc = *b.StartCol
+	}
+	return *b.StartLine, sc
+}
+
+func posInRange(l, c int, start, end token.Position) bool {
+	if l < start.Line || l > end.Line {
+		return false
+	}
+	if l == start.Line && c != 0 && c < start.Column {
+		return false
+	}
+	if l == end.Line && c > end.Column {
+		return false
+	}
+	return true
+}
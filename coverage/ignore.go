@@ -0,0 +1,202 @@
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const ignorePragma = "octocov:ignore"
+
+// ignorePragmaRe matches `octocov:ignore`, `octocov:ignore-start`, `octocov:ignore-end` and `octocov:ignore-next-line` in comments.
+var ignorePragmaRe = regexp.MustCompile(`(?://|#|/\*|--|;|%)\s*octocov:ignore(-start|-end|-next-line)?(?:[^\w-]|$)`)
+
+// IgnoredLines are the lines of a source file ignored by the `octocov:ignore` pragmas.
+type IgnoredLines struct {
+	lines map[int]bool
+	src   []string
+}
+
+// ParseIgnoredLines returns the lines ignored by the pragmas in the source.
+//
+//   - `octocov:ignore` ignores the line it is written on.
+//   - `octocov:ignore-next-line` ignores the next line.
+//   - `octocov:ignore-start` and `octocov:ignore-end` ignore the lines between them, inclusive. An unterminated `octocov:ignore-start` ignores the rest of the file.
+//
+// It returns nil if the source contains no pragmas.
+func ParseIgnoredLines(src []byte) *IgnoredLines {
+	if !bytes.Contains(src, []byte(ignorePragma)) {
+		return nil
+	}
+	il := &IgnoredLines{
+		lines: map[int]bool{},
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(src)+1)
+	start := 0
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := scanner.Text()
+		il.src = append(il.src, line)
+		if start > 0 {
+			il.lines[n] = true
+		}
+		m := ignorePragmaRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		switch m[1] {
+		case "":
+			il.lines[n] = true
+		case "-next-line":
+			il.lines[n+1] = true
+		case "-start":
+			if start == 0 {
+				start = n
+			}
+			il.lines[n] = true
+		case "-end":
+			start = 0
+		}
+	}
+	if len(il.lines) == 0 {
+		return nil
+	}
+	return il
+}
+
+// Contains reports whether the line is ignored.
+func (il *IgnoredLines) Contains(n int) bool {
+	if il == nil {
+		return false
+	}
+	return il.lines[n]
+}
+
+// ignores reports whether the block is ignored.
+// A block is ignored when all of its lines containing code (not only brackets) are ignored.
+func (il *IgnoredLines) ignores(b *BlockCoverage) bool {
+	sl, el := *b.StartLine, *b.EndLine
+	code := false
+	for n := sl; n <= el; n++ {
+		if !il.hasCode(b, n) {
+			continue
+		}
+		code = true
+		if !il.lines[n] {
+			return false
+		}
+	}
+	if code {
+		return true
+	}
+	for n := sl; n <= el; n++ {
+		if !il.lines[n] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasCode reports whether the part of the line in the block contains code other than brackets.
+func (il *IgnoredLines) hasCode(b *BlockCoverage, n int) bool {
+	if n < 1 || n > len(il.src) {
+		return true
+	}
+	text := il.src[n-1]
+	if n == *b.EndLine && b.EndCol != nil && *b.EndCol > 0 {
+		text = text[:min(*b.EndCol-1, len(text))]
+	}
+	if n == *b.StartLine && b.StartCol != nil && *b.StartCol > 0 {
+		text = text[min(*b.StartCol-1, len(text)):]
+	}
+	return strings.Trim(text, " \t{}()[];,") != ""
+}
+
+func (il *IgnoredLines) overlaps(start, end int) bool {
+	for n := start; n <= end; n++ {
+		if il.lines[n] {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyIgnoredLines removes the blocks, branches and functions on the ignored lines.
+// Blocks of lines partially ignored are split into lines, except for statement blocks which cannot be split.
+// Total and Covered are updated by reCalc.
+func (fc *FileCoverage) ApplyIgnoredLines(il *IgnoredLines) {
+	if il == nil {
+		return
+	}
+	var s *blockSlab
+	blocks := make(BlockCoverages, 0, len(fc.Blocks))
+	for _, b := range fc.Blocks {
+		if il.ignores(b) {
+			continue
+		}
+		if b.Type == TypeStmt || *b.StartLine == *b.EndLine || !il.overlaps(*b.StartLine, *b.EndLine) {
+			blocks = append(blocks, b)
+			continue
+		}
+		if s == nil {
+			s = newBlockSlab(0)
+		}
+		for n := *b.StartLine; n <= *b.EndLine; n++ {
+			if il.lines[n] {
+				continue
+			}
+			lb := s.lineBlock(n, *b.Count)
+			lb.Label = b.Label
+			blocks = append(blocks, lb)
+		}
+	}
+	fc.Blocks = blocks
+	fc.cache = nil
+
+	if len(fc.Branches) > 0 {
+		branches := make(BranchCoverages, 0, len(fc.Branches))
+		for _, b := range fc.Branches {
+			if !il.lines[b.Line] {
+				branches = append(branches, b)
+			}
+		}
+		fc.setBranches(branches)
+	}
+
+	if len(fc.Functions) > 0 {
+		funcs := make(FunctionCoverages, 0, len(fc.Functions))
+		for _, f := range fc.Functions {
+			if !il.lines[f.StartLine] {
+				funcs = append(funcs, f)
+			}
+		}
+		fc.setFunctions(funcs)
+	}
+}
+
+// ApplyIgnorePragmas applies the `octocov:ignore` pragmas in the source files of the normalized files.
+// Files not found on the filesystem are skipped.
+func (c *Coverage) ApplyIgnorePragmas(root string) {
+	if c == nil || root == "" {
+		return
+	}
+	for _, f := range c.Files {
+		if f.NormalizedPath == "" {
+			continue
+		}
+		p := f.NormalizedPath
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(root, p)
+		}
+		src, err := os.ReadFile(filepath.Clean(p))
+		if err != nil {
+			continue
+		}
+		f.ApplyIgnoredLines(ParseIgnoredLines(src))
+	}
+}
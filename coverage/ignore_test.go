@@ -0,0 +1,127 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseIgnoredLines(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []int
+	}{
+		{"a := 1\nb := 2\n", nil},
+		{"a := 1 // octocov:ignore\nb := 2\n", []int{1}},
+		{"a = 1  # octocov:ignore\nb = 2\n", []int{1}},
+		{"/* octocov:ignore */ a := 1\nb := 2\n", []int{1}},
+		{"# octocov:ignore-next-line\na = 1\nb = 2\n", []int{2}},
+		{"a := 1\n// octocov:ignore-start\nb := 2\nc := 3\n// octocov:ignore-end\nd := 4\n", []int{2, 3, 4, 5}},
+		{"a := 1\n// octocov:ignore-start\nb := 2\n", []int{2, 3}},
+		{"a := 1\n// octocov:ignore-end\nb := 2\n", nil},
+		{"a := \"octocov:ignore\"\n", nil},
+		{"a := 1 // octocov:ignored\n", nil},
+		{"a := 1 // octocov:ignore-foo\n", nil},
+	}
+	for _, tt := range tests {
+		il := ParseIgnoredLines([]byte(tt.src))
+		var got []int
+		for n := 1; n <= 10; n++ {
+			if il.Contains(n) {
+				got = append(got, n)
+			}
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%q: %s", tt.src, diff)
+		}
+	}
+}
+
+func TestApplyIgnoredLines(t *testing.T) {
+	src := `package main
+
+func f(in string) error {
+	if in == "" {
+		return errors.New("empty") // octocov:ignore
+	}
+	return nil
+}
+
+// octocov:ignore-start
+func g() {
+	println("g")
+}
+// octocov:ignore-end
+`
+	tests := []struct {
+		name      string
+		fc        *FileCoverage
+		want      BlockCoverages
+		wantFuncs int
+	}{
+		{
+			"loc",
+			&FileCoverage{
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 1),
+					newBlockCoverage(TypeLOC, 5, -1, 5, -1, -1, 0),
+					newBlockCoverage(TypeLOC, 7, -1, 7, -1, -1, 1),
+					newBlockCoverage(TypeLOC, 12, -1, 12, -1, -1, 0),
+				},
+				Functions: FunctionCoverages{
+					{Name: "f", StartLine: 3, Count: 1},
+					{Name: "g", StartLine: 11, Count: 0},
+				},
+			},
+			BlockCoverages{
+				newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 1),
+				newBlockCoverage(TypeLOC, 7, -1, 7, -1, -1, 1),
+			},
+			1,
+		},
+		{
+			"loc block partially ignored",
+			&FileCoverage{
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeLOC, 4, -1, 7, -1, -1, 1),
+				},
+			},
+			BlockCoverages{
+				newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 1),
+				newBlockCoverage(TypeLOC, 6, -1, 6, -1, -1, 1),
+				newBlockCoverage(TypeLOC, 7, -1, 7, -1, -1, 1),
+			},
+			0,
+		},
+		{
+			"statement",
+			&FileCoverage{
+				Type: TypeStmt,
+				Blocks: BlockCoverages{
+					newBlockCoverage(TypeStmt, 3, 32, 4, 14, 1, 1),
+					newBlockCoverage(TypeStmt, 4, 14, 6, 3, 1, 0),
+					newBlockCoverage(TypeStmt, 7, 2, 7, 12, 1, 1),
+					newBlockCoverage(TypeStmt, 11, 10, 13, 2, 1, 0),
+				},
+			},
+			BlockCoverages{
+				newBlockCoverage(TypeStmt, 3, 32, 4, 14, 1, 1),
+				newBlockCoverage(TypeStmt, 7, 2, 7, 12, 1, 1),
+			},
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fc.ApplyIgnoredLines(ParseIgnoredLines([]byte(src)))
+			if diff := cmp.Diff(tt.want, tt.fc.Blocks); diff != "" {
+				t.Error(diff)
+			}
+			if got := tt.fc.FunctionTotal; got != tt.wantFuncs {
+				t.Errorf("got %v\nwant %v", got, tt.wantFuncs)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package coverage
+
+import (
+	"path/filepath"
+)
+
+// Include adds the source files matching the patterns but missing from the coverage as uncovered files.
+// files are the paths of the source files under root, and codeLines returns the coverable lines of a file.
+// Files whose coverable lines cannot be estimated are not added.
+// Total and Covered are updated by reCalc.
+func (c *Coverage) Include(root string, files, patterns []string, codeLines func(path string) ([]int, bool)) error {
+	if c == nil || root == "" || len(patterns) == 0 {
+		return nil
+	}
+	measured := map[string]struct{}{}
+	for _, f := range c.Files {
+		measured[f.EffectivePath()] = struct{}{}
+	}
+	var added FileCoverages
+	for _, p := range files {
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		fc := &FileCoverage{File: rel, NormalizedPath: rel}
+		match, err := fc.Match(patterns)
+		if err != nil {
+			return err
+		}
+		if !match {
+			continue
+		}
+		if _, ok := measured[rel]; ok {
+			continue
+		}
+		if _, err := c.Files.FuzzyFindByFile(rel); err == nil {
+			continue
+		}
+		lines, ok := codeLines(p)
+		if !ok || len(lines) == 0 {
+			continue
+		}
+		added = append(added, newUncoveredFileCoverage(fc, c.Type, lines))
+	}
+	c.Files = append(c.Files, added...)
+	return nil
+}
+
+// newUncoveredFileCoverage fills the file with the blocks of the lines never executed.
+// The columns of the lines are unknown, so each block covers its whole line.
+// Each line is estimated as a statement for the coverage of statements.
+func newUncoveredFileCoverage(fc *FileCoverage, t Type, lines []int) *FileCoverage {
+	fc.Type = TypeLOC
+	if t == TypeStmt {
+		fc.Type = TypeStmt
+	}
+	fc.Blocks = make(BlockCoverages, 0, len(lines))
+	s := newBlockSlab(len(lines))
+	ns := 1
+	for _, l := range lines {
+		b := s.lineBlock(l, 0)
+		if t == TypeStmt {
+			b.NumStmt = &ns
+		}
+		fc.Blocks = append(fc.Blocks, b)
+	}
+	fc.included = true
+	return fc
+}
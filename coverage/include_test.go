@@ -0,0 +1,70 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInclude(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	files := []string{
+		filepath.Join(root, "src", "app.js"),
+		filepath.Join(root, "src", "app.test.js"),
+		filepath.Join(root, "src", "unknown.js"),
+		filepath.Join(root, "src", "untested.js"),
+		filepath.Join(root, "docs", "index.md"),
+	}
+	codeLines := func(p string) ([]int, bool) {
+		if filepath.Base(p) == "unknown.js" {
+			return nil, false
+		}
+		return []int{1, 3}, true
+	}
+	tests := []struct {
+		typ         Type
+		patterns    []string
+		wantFiles   []string
+		wantTotal   int
+		wantCovered int
+	}{
+		{TypeLOC, nil, []string{"/abs/src/app.js"}, 2, 1},
+		{TypeLOC, []string{"src/**/*.js", "!src/**/*.test.js"}, []string{"/abs/src/app.js", "src/untested.js"}, 4, 1},
+		{TypeStmt, []string{"src/**/*.js", "!src/**/*.test.js"}, []string{"/abs/src/app.js", "src/untested.js"}, 4, 1},
+	}
+	for _, tt := range tests {
+		c := &Coverage{
+			Type: tt.typ,
+			Files: FileCoverages{
+				&FileCoverage{
+					File: "/abs/src/app.js",
+					Type: tt.typ,
+					Blocks: BlockCoverages{
+						newBlockCoverage(tt.typ, 1, -1, 1, -1, 1, 1),
+						newBlockCoverage(tt.typ, 2, -1, 2, -1, 1, 0),
+					},
+				},
+			},
+		}
+		if err := c.Include(root, files, tt.patterns, codeLines); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Exclude(nil); err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, f := range c.Files {
+			got = append(got, f.File)
+		}
+		if diff := cmp.Diff(tt.wantFiles, got); diff != "" {
+			t.Error(diff)
+		}
+		if c.Total != tt.wantTotal {
+			t.Errorf("got %v\nwant %v", c.Total, tt.wantTotal)
+		}
+		if c.Covered != tt.wantCovered {
+			t.Errorf("got %v\nwant %v", c.Covered, tt.wantCovered)
+		}
+	}
+}
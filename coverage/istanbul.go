@@ -0,0 +1,213 @@
+package coverage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+var _ Processor = (*Istanbul)(nil)
+
+var IstanbulDefaultPath = []string{"coverage", "coverage-final.json"}
+
+type Istanbul struct{}
+
+// IstanbulReport is the Istanbul coverage-final.json format written by nyc, c8, jest and vitest.
+type IstanbulReport map[string]*IstanbulFileCoverage
+
+type IstanbulFileCoverage struct {
+	Path         string                    `json:"path"`
+	StatementMap map[string]IstanbulRange  `json:"statementMap"`
+	FnMap        map[string]IstanbulFunc   `json:"fnMap"`
+	BranchMap    map[string]IstanbulBranch `json:"branchMap"`
+	S            map[string]int            `json:"s"`
+	F            map[string]int            `json:"f"`
+	B            map[string][]int          `json:"b"`
+}
+
+type IstanbulPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type IstanbulRange struct {
+	Start IstanbulPosition `json:"start"`
+	End   IstanbulPosition `json:"end"`
+}
+
+type IstanbulFunc struct {
+	Name string        `json:"name"`
+	Decl IstanbulRange `json:"decl"`
+	Loc  IstanbulRange `json:"loc"`
+	Line int           `json:"line"`
+}
+
+type IstanbulBranch struct {
+	Loc       IstanbulRange   `json:"loc"`
+	Type      string          `json:"type"`
+	Locations []IstanbulRange `json:"locations"`
+	Line      int             `json:"line"`
+}
+
+func NewIstanbul() *Istanbul {
+	return &Istanbul{}
+}
+
+func (i *Istanbul) Name() string {
+	return "Istanbul"
+}
+
+func (i *Istanbul) ParseReport(path string) (*Coverage, string, error) {
+	rp, err := i.detectReportPath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := os.ReadFile(filepath.Clean(rp))
+	if err != nil {
+		return nil, "", err
+	}
+	r := IstanbulReport{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, "", err
+	}
+	if len(r) == 0 {
+		return nil, "", fmt.Errorf("%s is not Istanbul format", filepath.Clean(rp))
+	}
+	keys := make([]string, 0, len(r))
+	for k, f := range r {
+		if f == nil || f.Path == "" || f.StatementMap == nil || f.S == nil {
+			return nil, "", fmt.Errorf("%s is not Istanbul format", filepath.Clean(rp))
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	cov := New()
+	cov.Type = TypeStmt
+	cov.Format = i.Name()
+	for _, k := range keys {
+		fcov := r[k].toFileCoverage()
+		cov.Total += fcov.Total
+		cov.Covered += fcov.Covered
+		cov.BranchTotal += fcov.BranchTotal
+		cov.BranchCovered += fcov.BranchCovered
+		cov.FunctionTotal += fcov.FunctionTotal
+		cov.FunctionCovered += fcov.FunctionCovered
+		cov.Files = append(cov.Files, fcov)
+	}
+	return cov, rp, nil
+}
+
+func (f *IstanbulFileCoverage) toFileCoverage() *FileCoverage {
+	fcov := NewFileCoverage(f.Path, TypeStmt)
+	var spans []span
+	for _, id := range sortedIstanbulIDs(f.StatementMap) {
+		c := toExecCount(f.S[id])
+		spans = append(spans, newIstanbulSpan(f.StatementMap[id], 1, c))
+		fcov.Total += 1
+		if c > 0 {
+			fcov.Covered += 1
+		}
+	}
+	// Functions and branches are not statements, so they are mapped as zero-statement
+	// spans. Only the ones never taken are mapped: they mark the uncovered part of a
+	// line precisely, while covered ones carry nothing the statements do not already show.
+	var funcs FunctionCoverages
+	for _, id := range sortedIstanbulIDs(f.FnMap) {
+		fn := f.FnMap[id]
+		funcs = append(funcs, &FunctionCoverage{Name: fn.Name, StartLine: fn.Loc.Start.Line, EndLine: fn.Loc.End.Line, Count: toExecCount(f.F[id])})
+		if f.F[id] > 0 {
+			continue
+		}
+		spans = append(spans, newIstanbulSpan(fn.Decl, 0, 0))
+	}
+	var branches BranchCoverages
+	for _, id := range sortedIstanbulIDs(f.BranchMap) {
+		br := f.BranchMap[id]
+		counts := f.B[id]
+		// Each location is a branch; they are attributed to the line where the branch starts.
+		covered := 0
+		for _, c := range counts {
+			if c > 0 {
+				covered++
+			}
+		}
+		branches = branches.addBranches(br.Loc.Start.Line, len(counts), covered)
+		for j, loc := range br.Locations {
+			if j >= len(counts) || counts[j] > 0 {
+				continue
+			}
+			// e.g. the implicit else of an `if` reports the whole statement as its location.
+			if loc == br.Loc {
+				continue
+			}
+			spans = append(spans, newIstanbulSpan(loc, 0, 0))
+		}
+	}
+	// Istanbul statements nest (e.g. an `if` and the statements of its body).
+	fcov.Blocks = flattenSpans(spans)
+	fcov.setBranches(branches)
+	fcov.setFunctions(funcs)
+	return fcov
+}
+
+// newIstanbulSpan converts an Istanbul range (1-based lines, 0-based columns, exclusive end)
+// into a span (1-based columns, inclusive end).
+func newIstanbulSpan(r IstanbulRange, numStmt int, c ExecCount) span {
+	s := span{
+		startLine: r.Start.Line,
+		startCol:  r.Start.Column + 1,
+		endLine:   r.End.Line,
+		endCol:    r.End.Column,
+		numStmt:   numStmt,
+		count:     c,
+	}
+	if s.endLine < s.startLine {
+		s.endLine = s.startLine
+	}
+	if s.startLine == s.endLine && s.endCol < s.startCol {
+		s.endCol = s.startCol
+	}
+	return s
+}
+
+// sortedIstanbulIDs returns the keys of an Istanbul map ordered numerically.
+func sortedIstanbulIDs[T any](m map[string]T) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		ni, erri := strconv.Atoi(ids[i])
+		nj, errj := strconv.Atoi(ids[j])
+		if erri != nil || errj != nil {
+			return ids[i] < ids[j]
+		}
+		return ni < nj
+	})
+	return ids
+}
+
+func (i *Istanbul) detectReportPath(path string) (string, error) {
+	p, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if p.IsDir() {
+		// path/to/coverage/coverage-final.json
+		np := filepath.Join(path, IstanbulDefaultPath[0], IstanbulDefaultPath[1])
+		if _, err := os.Stat(np); err != nil {
+			// path/to/coverage-final.json
+			np = filepath.Join(path, IstanbulDefaultPath[1])
+			if _, err := os.Stat(np); err != nil {
+				return "", err
+			}
+		}
+		path = np
+	}
+	return path, nil
+}
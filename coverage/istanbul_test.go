@@ -0,0 +1,113 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestIstanbul(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "istanbul")
+	istanbul := NewIstanbul()
+	got, _, err := istanbul.ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 11; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 9; got.Covered != want {
+		t.Errorf("got %v\nwant %v", got.Covered, want)
+	}
+	if want := 2; len(got.Files) != want {
+		t.Fatalf("got %v\nwant %v", len(got.Files), want)
+	}
+	if want := "/home/runner/work/app/app/src/math.js"; got.Files[0].File != want {
+		t.Errorf("got %v\nwant %v", got.Files[0].File, want)
+	}
+
+	for _, f := range got.Files {
+		total := 0
+		covered := 0
+		for _, b := range f.Blocks {
+			// Statement
+			total = total + *b.NumStmt
+			if *b.Count > 0 {
+				covered += *b.NumStmt
+			}
+		}
+		if got := f.Total; got != total {
+			t.Errorf("got %v\nwant %v", got, total)
+		}
+		if got := f.Covered; got != covered {
+			t.Errorf("got %v\nwant %v", got, covered)
+		}
+	}
+}
+
+func TestIstanbulColumns(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json")
+	got, _, err := NewIstanbul().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := got.Files[0]
+	lcs := fc.Blocks.ToLineCoverages()
+
+	// `return a < 0 ? -1 : 1;` on line 6: the `1` alternate branch (column 29) is never taken.
+	lc, err := lcs.FindByLine(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ExecCount(3); lc.Count != want {
+		t.Errorf("got %v\nwant %v", lc.Count, want)
+	}
+	if c, err := lc.PosCoverages.FindCountByPos(3); err != nil || c == 0 {
+		t.Errorf("got %v, %v\nwant covered", c, err)
+	}
+	if c, err := lc.PosCoverages.FindCountByPos(30); err != nil || c != 0 {
+		t.Errorf("got %v, %v\nwant uncovered", c, err)
+	}
+
+	// The implicit else of `if` on line 10 must not be mapped.
+	for _, b := range fc.FindBlocksByLine(10) {
+		if *b.NumStmt == 0 {
+			t.Errorf("unexpected zero-statement block on line 10: %v-%v", *b.StartLine, *b.EndLine)
+		}
+	}
+
+	// The uncovered function name is mapped in unused.js.
+	found := false
+	for _, b := range got.Files[1].Blocks {
+		if *b.NumStmt == 0 && *b.StartLine == 1 && *b.StartCol == 10 && *b.EndCol == 13 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("uncovered function block not found")
+	}
+}
+
+func TestIstanbulParseAllFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{filepath.Join(testdataDir(t), "gocover", "coverage.out"), true},
+		{filepath.Join(testdataDir(t), "lcov", "lcov.info"), true},
+		{filepath.Join(testdataDir(t), "simplecov", ".resultset.json"), true},
+		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "cobertura", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), true},
+		{filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json"), false},
+		{filepath.Join(testdataDir(t), "coveragepy", "coverage.json"), true},
+		{filepath.Join(testdataDir(t), "opencover", "coverage.opencover.xml"), true},
+		{filepath.Join(testdataDir(t), "gcov", "main.gcov.json.gz"), true},
+		{filepath.Join(testdataDir(t), "sonar", "coverage.xml"), true},
+	}
+	for _, tt := range tests {
+		_, _, err := NewIstanbul().ParseReport(tt.path)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package coverage
 import (
 	"encoding/xml"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 var _ Processor = (*Jacoco)(nil)
@@ -96,7 +99,26 @@ func (c *Jacoco) ParseReport(path string) (*Coverage, string, error) {
 	cov.Format = c.Name()
 
 	flm := map[string]BlockCoverages{}
+	fbm := map[string]BranchCoverages{}
+	ffm := map[string]FunctionCoverages{}
 	for _, p := range r.Package {
+		for _, cl := range p.Class {
+			if cl.Sourcefilename == "" {
+				continue
+			}
+			n := fmt.Sprintf("%s/%s", p.Name, cl.Sourcefilename)
+			cn := cl.Name[strings.LastIndex(cl.Name, "/")+1:]
+			for _, m := range cl.Method {
+				// JaCoCo does not record the number of calls, only whether the method was executed.
+				c := ExecCount(0)
+				for _, counter := range m.Counter {
+					if counter.Type == "METHOD" && counter.Covered > 0 {
+						c = 1
+					}
+				}
+				ffm[n] = append(ffm[n], &FunctionCoverage{Name: fmt.Sprintf("%s.%s", cn, m.Name), StartLine: m.Line, Count: c})
+			}
+		}
 		for _, s := range p.Sourcefile {
 			n := fmt.Sprintf("%s/%s", p.Name, s.Name)
 			f, ok := flm[n]
@@ -116,12 +138,14 @@ func (c *Jacoco) ParseReport(path string) (*Coverage, string, error) {
 					EndLine:   &el,
 					Count:     &c,
 				})
+				fbm[n] = fbm[n].addBranches(l.Nr, l.Mb+l.Cb, l.Cb)
 			}
 			flm[n] = f
 		}
 	}
 
-	for f, blocks := range flm {
+	for _, f := range slices.Sorted(maps.Keys(flm)) {
+		blocks := flm[f]
 		fcov := NewFileCoverage(f, TypeLOC)
 		for _, b := range blocks {
 			fcov.Total += 1
@@ -130,8 +154,14 @@ func (c *Jacoco) ParseReport(path string) (*Coverage, string, error) {
 			}
 		}
 		fcov.Blocks = blocks
+		fcov.setBranches(fbm[f])
+		fcov.setFunctions(ffm[f])
 		cov.Total += fcov.Total
 		cov.Covered += fcov.Covered
+		cov.BranchTotal += fcov.BranchTotal
+		cov.BranchCovered += fcov.BranchCovered
+		cov.FunctionTotal += fcov.FunctionTotal
+		cov.FunctionCovered += fcov.FunctionCovered
 		cov.Files = append(cov.Files, fcov)
 	}
 
@@ -10,7 +10,7 @@ import (
 	"strings"
 )
 
-var _ Processor = (*Lcov)(nil)
+var _ StreamProcessor = (*Lcov)(nil)
 
 var LcovDefaultPath = []string{"coverage", "lcov.info"}
 
@@ -25,61 +25,95 @@ func (l *Lcov) Name() string {
 }
 
 func (l *Lcov) ParseReport(path string) (*Coverage, string, error) {
-	rp, err := l.detectReportPath(path)
+	b := NewBuilder()
+	rp, err := l.StreamReport(path, func(fc *FileCoverage) error {
+		b.AddFile(l.Name(), fc)
+		return nil
+	})
 	if err != nil {
 		return nil, "", err
 	}
-	r, err := os.Open(filepath.Clean(rp))
+	cov, err := b.Coverage()
 	if err != nil {
 		return nil, "", err
 	}
+	return cov, rp, nil
+}
+
+// lcovMaxLineSize is the maximum length of a line of LCOV tracefiles (e.g. long mangled function names).
+const lcovMaxLineSize = 16 * 1024 * 1024
+
+// StreamReport parses the LCOV tracefile record by record and calls fn with the coverage of each record.
+func (l *Lcov) StreamReport(path string, fn func(fc *FileCoverage) error) (string, error) {
+	rp, err := l.detectReportPath(path)
+	if err != nil {
+		return "", err
+	}
+	r, err := os.Open(filepath.Clean(rp))
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
 	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), lcovMaxLineSize)
 	var (
-		fileName       string
-		total, covered int
+		fileName string
+		lines    []lineCount
+		branches BranchCoverages
+		funcs    FunctionCoverages
 	)
-	cov := New()
-	cov.Type = TypeLOC
-	cov.Format = l.Name()
 	parsed := false
-	blocks := BlockCoverages{}
 	for scanner.Scan() {
 		l := scanner.Text()
 		if l == "end_of_record" {
-			fcov, err := cov.Files.FindByFile(fileName)
-			if err != nil {
-				fcov = NewFileCoverage(fileName, TypeLOC)
+			fcov := NewFileCoverage(fileName, TypeLOC)
+			s := newBlockSlab(len(lines))
+			fcov.Blocks = make(BlockCoverages, 0, len(lines))
+			for _, lc := range lines {
+				fcov.Total += 1
+				if lc.count > 0 {
+					fcov.Covered += 1
+				}
+				fcov.Blocks = append(fcov.Blocks, s.lineBlock(lc.line, lc.count))
+			}
+			fcov.setBranches(branches)
+			fcov.setFunctions(funcs)
+			if err := fn(fcov); err != nil {
+				return "", err
 			}
-			fcov.Total += total
-			fcov.Covered += covered
-			fcov.Blocks = blocks
-			cov.Total += total
-			cov.Covered += covered
-			cov.Files = append(cov.Files, fcov)
-			total = 0
-			covered = 0
 			parsed = true
-			blocks = BlockCoverages{}
+			lines = lines[:0]
+			branches = nil
+			funcs = nil
+			continue
+		}
+		k, v, ok := strings.Cut(l, ":")
+		if !ok {
+			continue
+		}
+		// Function names may contain ':' (e.g. C++ namespaces).
+		if k == "FN" || k == "FNDA" {
+			var err error
+			funcs, err = parseLcovFunction(funcs, k, v)
+			if err != nil {
+				return "", fmt.Errorf("can not parse: %s: %w", l, err)
+			}
 			continue
 		}
-		splitted := strings.Split(l, ":")
-		if len(splitted) != 2 {
+		if strings.Contains(v, ":") {
 			continue
 		}
-		switch splitted[0] {
+		switch k {
 		case "SF":
-			fileName = splitted[1]
+			fileName = v
 		case "DA":
-			total += 1
-			nums := strings.Split(splitted[1], ",")
-			if len(nums) != 2 {
-				_ = r.Close() //nostyle:handlerrors
-				return nil, "", fmt.Errorf("can not parse: %s", l)
+			ls, cs, ok := strings.Cut(v, ",")
+			if !ok || strings.Contains(cs, ",") {
+				return "", fmt.Errorf("can not parse: %s", l)
 			}
-			line, err := strconv.Atoi(nums[0])
+			line, err := strconv.Atoi(ls)
 			if err != nil {
-				_ = r.Close() //nostyle:handlerrors
-				return nil, "", err
+				return "", err
 			}
 			// Parse as uint64: llvm-cov can emit u64-wrapped (negative)
 			// execution counts when profile counters race (e.g. a thread
@@ -87,32 +121,80 @@ func (l *Lcov) ParseReport(path string) (*Coverage, string, error) {
 			// valid input. On ErrRange (> MaxUint64) ParseUint has already
 			// saturated the value; accept it instead of rejecting the whole
 			// report over one corrupt counter.
-			count, err := strconv.ParseUint(nums[1], 10, 64)
+			count, err := strconv.ParseUint(cs, 10, 64)
 			if err != nil && !errors.Is(err, strconv.ErrRange) {
-				_ = r.Close() //nostyle:handlerrors
-				return nil, "", err
+				return "", err
 			}
-			if count > 0 {
-				covered += 1
+			lines = append(lines, lineCount{line: line, count: ExecCount(count)})
+		case "BRDA":
+			// BRDA:<line>,<block>,<branch>,<taken>
+			nums := strings.Split(v, ",")
+			if len(nums) != 4 {
+				return "", fmt.Errorf("can not parse: %s", l)
 			}
-			c := ExecCount(count)
-			blocks = append(blocks, &BlockCoverage{
-				Type:      TypeLOC,
-				StartLine: &line,
-				EndLine:   &line,
-				Count:     &c,
-			})
+			line, err := strconv.Atoi(nums[0])
+			if err != nil {
+				return "", err
+			}
+			// "-" means the block containing the branch was never executed.
+			branches = branches.addTaken(line, nums[3] != "-" && nums[3] != "0")
 		default:
 			// not implemented
 		}
 	}
-	if err := r.Close(); err != nil {
-		return nil, "", err
+	if err := scanner.Err(); err != nil {
+		return "", err
 	}
 	if !parsed {
-		return nil, "", errors.New("can not parse")
+		return "", errors.New("can not parse")
 	}
-	return cov, rp, nil
+	return rp, nil
+}
+
+// parseLcovFunction parses `FN:<line>,<name>` (or `FN:<start line>,<end line>,<name>` of lcov 2.x) and `FNDA:<count>,<name>`.
+func parseLcovFunction(funcs FunctionCoverages, k, v string) (FunctionCoverages, error) {
+	switch k {
+	case "FN":
+		ls, name, ok := strings.Cut(v, ",")
+		if !ok {
+			return nil, errors.New("invalid FN")
+		}
+		sl, err := strconv.Atoi(ls)
+		if err != nil {
+			return nil, err
+		}
+		el := 0
+		if le, n, ok := strings.Cut(name, ","); ok {
+			if e, err := strconv.Atoi(le); err == nil {
+				el = e
+				name = n
+			}
+		}
+		if f, err := funcs.FindByName(name); err == nil {
+			// FNDA appeared before FN.
+			f.StartLine = sl
+			f.EndLine = el
+			return funcs, nil
+		}
+		return append(funcs, &FunctionCoverage{Name: name, StartLine: sl, EndLine: el}), nil
+	case "FNDA":
+		cs, name, ok := strings.Cut(v, ",")
+		if !ok {
+			return nil, errors.New("invalid FNDA")
+		}
+		count, err := strconv.ParseUint(cs, 10, 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return nil, err
+		}
+		f, err := funcs.FindByName(name)
+		if err != nil {
+			f = &FunctionCoverage{Name: name}
+			funcs = append(funcs, f)
+		}
+		f.Count = satAdd(f.Count, ExecCount(count))
+		return funcs, nil
+	}
+	return funcs, nil
 }
 
 func (l *Lcov) detectReportPath(path string) (string, error) {
@@ -0,0 +1,278 @@
+package coverage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+var _ Processor = (*LLVMCov)(nil)
+
+const LLVMCovDefaultPath = "coverage.json"
+
+const llvmCovExportType = "llvm.coverage.json.export"
+
+type LLVMCov struct{}
+
+// LLVMCovReport is the JSON written by `llvm-cov export -format=text` (and `cargo llvm-cov --json`).
+type LLVMCovReport struct {
+	Type    string              `json:"type"`
+	Version string              `json:"version"`
+	Data    []LLVMCovReportData `json:"data"`
+}
+
+type LLVMCovReportData struct {
+	Files     []LLVMCovReportFile     `json:"files"`
+	Functions []LLVMCovReportFunction `json:"functions"`
+}
+
+type LLVMCovReportFile struct {
+	Filename string            `json:"filename"`
+	Segments []LLVMCovSegment  `json:"segments"`
+	Branches []json.RawMessage `json:"branches"`
+}
+
+type LLVMCovReportFunction struct {
+	Name      string            `json:"name"`
+	Count     json.Number       `json:"count"`
+	Filenames []string          `json:"filenames"`
+	Regions   []json.RawMessage `json:"regions"`
+	Branches  []json.RawMessage `json:"branches"`
+}
+
+// LLVMCovSegment is a segment of the coverage mapping: the region count that applies
+// from this position up to the position of the next segment.
+type LLVMCovSegment struct {
+	Line          int
+	Col           int
+	Count         ExecCount
+	HasCount      bool
+	IsRegionEntry bool
+	IsGapRegion   bool
+}
+
+func NewLLVMCov() *LLVMCov {
+	return &LLVMCov{}
+}
+
+func (l *LLVMCov) Name() string {
+	return "llvm-cov"
+}
+
+func (l *LLVMCov) ParseReport(path string) (*Coverage, string, error) {
+	rp, err := l.detectReportPath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := os.ReadFile(filepath.Clean(rp))
+	if err != nil {
+		return nil, "", err
+	}
+	r := LLVMCovReport{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, "", err
+	}
+	if r.Type != llvmCovExportType {
+		return nil, "", fmt.Errorf("%s is not llvm-cov export format", filepath.Clean(rp))
+	}
+
+	// The code coverage is measured by lines like the line summary of llvm-cov,
+	// while the blocks keep the columns of the segments.
+	cov := New()
+	cov.Type = TypeLOC
+	cov.Format = l.Name()
+	for _, d := range r.Data {
+		for _, f := range d.Files {
+			fcov, err := cov.Files.FindByFile(f.Filename)
+			if err != nil {
+				fcov = NewFileCoverage(f.Filename, TypeLOC)
+				cov.Files = append(cov.Files, fcov)
+			}
+			fcov.Blocks = append(fcov.Blocks, llvmCovSegmentsToBlocks(f.Segments)...)
+			for _, raw := range f.Branches {
+				line, taken, err := llvmCovBranch(raw)
+				if err != nil {
+					return nil, "", err
+				}
+				fcov.Branches = fcov.Branches.addTaken(line, taken...)
+			}
+		}
+		for _, fn := range d.Functions {
+			f, err := llvmCovFunction(fn)
+			if err != nil {
+				return nil, "", err
+			}
+			if f == nil {
+				continue
+			}
+			// The first region of a function is its body in the file where it is defined.
+			fcov, err := cov.Files.FindByFile(fn.Filenames[0])
+			if err != nil {
+				continue
+			}
+			fcov.Functions = fcov.Functions.merge(FunctionCoverages{f})
+		}
+	}
+	for _, fcov := range cov.Files {
+		lcs := fcov.Blocks.ToLineCoverages()
+		fcov.Total = lcs.Total()
+		fcov.Covered = lcs.Covered()
+		fcov.setBranches(fcov.Branches)
+		fcov.setFunctions(fcov.Functions)
+		cov.Total += fcov.Total
+		cov.Covered += fcov.Covered
+		cov.BranchTotal += fcov.BranchTotal
+		cov.BranchCovered += fcov.BranchCovered
+		cov.FunctionTotal += fcov.FunctionTotal
+		cov.FunctionCovered += fcov.FunctionCovered
+	}
+	return cov, rp, nil
+}
+
+// llvmCovSegmentsToBlocks converts segments into column-precise blocks.
+// Each segment with a count spans up to the position just before the next segment, or up to the end
+// of the line before the next segment when the next segment starts on a later line, so that a region
+// does not spill onto the indentation of the line where the next region starts.
+// Gap regions (whitespace between code) and skipped regions are not coverable.
+func llvmCovSegmentsToBlocks(segments []LLVMCovSegment) BlockCoverages {
+	blocks := BlockCoverages{}
+	for i := 0; i+1 < len(segments); i++ {
+		s := segments[i]
+		if !s.HasCount || s.IsGapRegion {
+			continue
+		}
+		next := segments[i+1]
+		sl := s.Line
+		sc := s.Col
+		el := next.Line
+		ec := next.Col - 1
+		if el > sl {
+			el--
+			ec = endPos - 1
+		}
+		if el == sl && ec < sc {
+			continue
+		}
+		// NumStmt counts regions: a segment that resumes an enclosing region after a nested one is not a new region.
+		ns := 0
+		if s.IsRegionEntry {
+			ns = 1
+		}
+		c := s.Count
+		blocks = append(blocks, &BlockCoverage{
+			Type:      TypeStmt,
+			StartLine: &sl,
+			StartCol:  &sc,
+			EndLine:   &el,
+			EndCol:    &ec,
+			NumStmt:   &ns,
+			Count:     &c,
+		})
+	}
+	return blocks
+}
+
+// llvmCovFunction returns the function coverage of a function record. It returns nil if the function has no region.
+func llvmCovFunction(fn LLVMCovReportFunction) (*FunctionCoverage, error) {
+	if len(fn.Regions) == 0 || len(fn.Filenames) == 0 {
+		return nil, nil
+	}
+	var region []json.RawMessage
+	if err := json.Unmarshal(fn.Regions[0], &region); err != nil {
+		return nil, err
+	}
+	// [line, col, endLine, endCol, count, fileID, expandedFileID, kind]
+	if len(region) < 4 {
+		return nil, fmt.Errorf("invalid llvm-cov region: %s", string(fn.Regions[0]))
+	}
+	var sl, el int
+	if err := json.Unmarshal(region[0], &sl); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(region[2], &el); err != nil {
+		return nil, err
+	}
+	count, err := strconv.ParseUint(fn.Count.String(), 10, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return nil, err
+	}
+	return &FunctionCoverage{Name: fn.Name, StartLine: sl, EndLine: el, Count: ExecCount(count)}, nil
+}
+
+// llvmCovBranch returns the line of a branch region and whether each of its two outcomes (true and false) was taken.
+func llvmCovBranch(data []byte) (int, []bool, error) {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return 0, nil, err
+	}
+	// [line, col, endLine, endCol, trueCount, falseCount, fileID, expandedFileID, kind]
+	if len(fields) < 6 {
+		return 0, nil, fmt.Errorf("invalid llvm-cov branch: %s", string(data))
+	}
+	var line int
+	if err := json.Unmarshal(fields[0], &line); err != nil {
+		return 0, nil, err
+	}
+	taken := make([]bool, 0, 2)
+	for _, f := range fields[4:6] {
+		count, err := strconv.ParseUint(string(f), 10, 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return 0, nil, err
+		}
+		taken = append(taken, count > 0)
+	}
+	return line, taken, nil
+}
+
+func (s *LLVMCovSegment) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	// [line, col, count, hasCount, isRegionEntry(, isGapRegion)]
+	if len(fields) < 5 {
+		return fmt.Errorf("invalid llvm-cov segment: %s", string(data))
+	}
+	if err := json.Unmarshal(fields[0], &s.Line); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[1], &s.Col); err != nil {
+		return err
+	}
+	// Counts are uint64 and may be u64-wrapped when profile counters race (see ExecCount).
+	count, err := strconv.ParseUint(string(fields[2]), 10, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return err
+	}
+	s.Count = ExecCount(count)
+	if err := json.Unmarshal(fields[3], &s.HasCount); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[4], &s.IsRegionEntry); err != nil {
+		return err
+	}
+	if len(fields) > 5 {
+		if err := json.Unmarshal(fields[5], &s.IsGapRegion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *LLVMCov) detectReportPath(path string) (string, error) {
+	p, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if p.IsDir() {
+		path = filepath.Join(path, LLVMCovDefaultPath)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
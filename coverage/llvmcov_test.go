@@ -0,0 +1,124 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLLVMCov(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "llvmcov")
+	got, _, err := NewLLVMCov().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The line summary of the fixture.
+	if want := 9; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 6; got.Covered != want {
+		t.Errorf("got %v\nwant %v", got.Covered, want)
+	}
+	if want := 1; len(got.Files) != want {
+		t.Fatalf("got %v\nwant %v", len(got.Files), want)
+	}
+	// Recalculating the coverage (e.g. in MeasureCoverage) keeps the same numbers.
+	if err := got.Exclude(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != 9 || got.Covered != 6 {
+		t.Errorf("got %v/%v\nwant %v/%v", got.Covered, got.Total, 6, 9)
+	}
+	fc := got.Files[0]
+	regions := 0
+	for _, b := range fc.Blocks {
+		regions += *b.NumStmt
+	}
+	if want := 5; regions != want {
+		t.Errorf("got %v\nwant %v", regions, want)
+	}
+
+	lcs := fc.Blocks.ToLineCoverages()
+	lc, err := lcs.FindByLine(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		pos  int
+		want ExecCount
+	}{
+		{8, 3},  // condition
+		{16, 1}, // then-body
+	}
+	for _, tt := range tests {
+		c, err := lc.PosCoverages.FindCountByPos(tt.pos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != tt.want {
+			t.Errorf("pos %d: got %v\nwant %v", tt.pos, c, tt.want)
+		}
+	}
+	// The gap region between the condition and the then-body is not coverable.
+	if c, err := lc.PosCoverages.FindCountByPos(14); err == nil && c == 1 {
+		t.Errorf("pos 14: got %v\nwant not mapped", c)
+	}
+}
+
+func TestLLVMCovSegmentsDoNotSpillOntoNextLine(t *testing.T) {
+	segments := []LLVMCovSegment{
+		{Line: 1, Col: 5, Count: 3, HasCount: true, IsRegionEntry: true},
+		{Line: 2, Col: 5, Count: 0, HasCount: true, IsRegionEntry: true},
+		{Line: 2, Col: 9, Count: 0, HasCount: false},
+	}
+	lcs := llvmCovSegmentsToBlocks(segments).ToLineCoverages()
+	lc, err := lcs.FindByLine(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ExecCount(0); lc.Count != want {
+		t.Errorf("got %v\nwant %v", lc.Count, want)
+	}
+}
+
+func TestLLVMCovAcceptsU64WrappedCounts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "coverage.json")
+	content := `{"data":[{"files":[{"filename":"src/cache.rs","segments":[[1,1,18446744073709551611,true,true,false],[2,2,0,false,false,false]]}]}],"type":"llvm.coverage.json.export","version":"2.0.1"}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := NewLLVMCov().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ExecCount(18446744073709551611); *got.Files[0].Blocks[0].Count != want {
+		t.Errorf("got %v\nwant %v", *got.Files[0].Blocks[0].Count, want)
+	}
+}
+
+func TestLLVMCovParseAllFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{filepath.Join(testdataDir(t), "gocover", "coverage.out"), true},
+		{filepath.Join(testdataDir(t), "lcov", "lcov.info"), true},
+		{filepath.Join(testdataDir(t), "simplecov", ".resultset.json"), true},
+		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "cobertura", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), true},
+		{filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json"), true},
+		{filepath.Join(testdataDir(t), "coveragepy", "coverage.json"), true},
+		{filepath.Join(testdataDir(t), "llvmcov", "coverage.json"), false},
+		{filepath.Join(testdataDir(t), "opencover", "coverage.opencover.xml"), true},
+		{filepath.Join(testdataDir(t), "gcov", "main.gcov.json.gz"), true},
+		{filepath.Join(testdataDir(t), "sonar", "coverage.xml"), true},
+	}
+	for _, tt := range tests {
+		_, _, err := NewLLVMCov().ParseReport(tt.path)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+		}
+	}
+}
@@ -4,36 +4,10 @@ func (c *Coverage) Merge(c2 *Coverage) error {
 	if c2 == nil {
 		return c.reCalc()
 	}
-	// Type
-	switch {
-	case c2.Type == "":
-	case c.Type != TypeLOC || c2.Type != TypeLOC:
-		// If either is not LOC, merge as Merged
-		c.Type = TypeMerged
-	}
-	// Format
-	if c2.Format != "" {
-		if c.Format == "" {
-			c.Format = c2.Format
-		} else if c.Format != c2.Format {
-			c.Format = FormatMerged
-		}
-	}
-	// Files
+	b := newBuilderFrom(c)
+	b.mergeType(c2.Type, c2.Format)
 	for _, fc2 := range c2.Files {
-		fc, err := c.Files.FindByFile(fc2.EffectivePath())
-		if err != nil && fc2.EffectivePath() != fc2.File {
-			fc, err = c.Files.FindByFile(fc2.File)
-		}
-		if err == nil {
-			if fc2.Type != fc.Type {
-				fc.Type = TypeMerged
-			}
-			// Merged coverage should be counted as LOC as duplicate blocks may be stacked.
-			fc.Blocks = append(fc.Blocks, fc2.Blocks...)
-		} else {
-			c.Files = append(c.Files, fc2)
-		}
+		b.merge(fc2)
 	}
 	return c.reCalc()
 }
@@ -41,14 +15,32 @@ func (c *Coverage) Merge(c2 *Coverage) error {
 func (c *Coverage) reCalc() error {
 	total := 0
 	covered := 0
+	branchTotal := 0
+	branchCovered := 0
+	functionTotal := 0
+	functionCovered := 0
 	for _, f := range c.Files {
+		if f.CarriedForward {
+			// The blocks of the files carried forward may have been dropped from the stored report.
+			total += f.Total
+			covered += f.Covered
+			branchTotal += f.BranchTotal
+			branchCovered += f.BranchCovered
+			functionTotal += f.FunctionTotal
+			functionCovered += f.FunctionCovered
+			continue
+		}
 		var fileTotal, fileCovered int
 
 		switch c.Type {
 		case TypeLOC, TypeMerged:
-			lcs := f.Blocks.ToLineCoverages()
-			fileTotal = lcs.Total()
-			fileCovered = lcs.Covered()
+			lcs := f.Blocks.lineCounts()
+			fileTotal = len(lcs)
+			for _, lc := range lcs {
+				if lc.count > 0 {
+					fileCovered++
+				}
+			}
 
 		case TypeStmt: // Coverage of a single unmerged TypeStmt.
 			for _, b := range f.Blocks {
@@ -63,9 +55,26 @@ func (c *Coverage) reCalc() error {
 		f.Covered = fileCovered
 		total += fileTotal
 		covered += fileCovered
+
+		// Branches are only recorded by formats that measure them.
+		if len(f.Branches) > 0 {
+			f.setBranches(f.Branches)
+		}
+		branchTotal += f.BranchTotal
+		branchCovered += f.BranchCovered
+
+		if len(f.Functions) > 0 {
+			f.setFunctions(f.Functions)
+		}
+		functionTotal += f.FunctionTotal
+		functionCovered += f.FunctionCovered
 	}
 	c.Total = total
 	c.Covered = covered
+	c.BranchTotal = branchTotal
+	c.BranchCovered = branchCovered
+	c.FunctionTotal = functionTotal
+	c.FunctionCovered = functionCovered
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package coverage
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -752,3 +753,56 @@ func TestMerge(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkMerge(b *testing.B) {
+	const (
+		reports = 40
+		files   = 100
+		lines   = 50
+	)
+	newReports := func() []*Coverage {
+		covs := make([]*Coverage, reports)
+		for n := range covs {
+			c := New()
+			c.Type = TypeLOC
+			c.Format = "LCOV"
+			for i := range files {
+				fc := NewFileCoverage(fmt.Sprintf("src/file_%d.js", i), TypeLOC)
+				for l := 1; l <= lines; l++ {
+					fc.Blocks = append(fc.Blocks, newBlockCoverage(TypeLOC, l, -1, l, -1, -1, (l+n)%3))
+				}
+				c.Files = append(c.Files, fc)
+			}
+			covs[n] = c
+		}
+		return covs
+	}
+	b.Run("Coverage.Merge", func(b *testing.B) {
+		for b.Loop() {
+			b.StopTimer()
+			covs := newReports()
+			b.StartTimer()
+			for _, c := range covs[1:] {
+				if err := covs[0].Merge(c); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	for _, aggregateLines := range []bool{false, true} {
+		b.Run(fmt.Sprintf("Builder/aggregateLines=%v", aggregateLines), func(b *testing.B) {
+			for b.Loop() {
+				b.StopTimer()
+				covs := newReports()
+				b.StartTimer()
+				bl := NewBuilder(AggregateLines(aggregateLines))
+				for _, c := range covs {
+					bl.Add(c)
+				}
+				if _, err := bl.Coverage(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
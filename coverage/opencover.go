@@ -0,0 +1,233 @@
+package coverage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var _ Processor = (*OpenCover)(nil)
+
+const OpenCoverDefaultPath = "coverage.opencover.xml"
+
+// openCoverHiddenLine is the line number of hidden sequence points (0xFEEFEE).
+const openCoverHiddenLine = 16707566
+
+type OpenCover struct{}
+
+type OpenCoverReport struct {
+	XMLName xml.Name                `xml:"CoverageSession"`
+	Modules *OpenCoverReportModules `xml:"Modules"`
+}
+
+type OpenCoverReportModules struct {
+	Module []OpenCoverReportModule `xml:"Module"`
+}
+
+type OpenCoverReportModule struct {
+	ModuleName string `xml:"ModuleName"`
+	Files      struct {
+		File []struct {
+			UID      string `xml:"uid,attr"`
+			FullPath string `xml:"fullPath,attr"`
+		} `xml:"File"`
+	} `xml:"Files"`
+	Classes struct {
+		Class []struct {
+			FullName string `xml:"FullName"`
+			Methods  struct {
+				Method []OpenCoverReportMethod `xml:"Method"`
+			} `xml:"Methods"`
+		} `xml:"Class"`
+	} `xml:"Classes"`
+}
+
+type OpenCoverReportMethod struct {
+	Visited string `xml:"visited,attr"`
+	Name    string `xml:"Name"`
+	FileRef *struct {
+		UID string `xml:"uid,attr"`
+	} `xml:"FileRef"`
+	SequencePoints struct {
+		SequencePoint []OpenCoverReportSequencePoint `xml:"SequencePoint"`
+	} `xml:"SequencePoints"`
+	BranchPoints struct {
+		BranchPoint []OpenCoverReportBranchPoint `xml:"BranchPoint"`
+	} `xml:"BranchPoints"`
+}
+
+type OpenCoverReportSequencePoint struct {
+	Vc     int    `xml:"vc,attr"`  // visit count
+	Sl     int    `xml:"sl,attr"`  // start line
+	Sc     int    `xml:"sc,attr"`  // start column
+	El     int    `xml:"el,attr"`  // end line
+	Ec     int    `xml:"ec,attr"`  // end column (exclusive)
+	Bec    int    `xml:"bec,attr"` // branch exit count
+	Bev    int    `xml:"bev,attr"` // branch exits visited
+	FileID string `xml:"fileid,attr"`
+}
+
+type OpenCoverReportBranchPoint struct {
+	Vc        int    `xml:"vc,attr"`
+	Sl        int    `xml:"sl,attr"`
+	Path      int    `xml:"path,attr"`
+	Offset    int    `xml:"offset,attr"`
+	OffsetEnd int    `xml:"offsetend,attr"`
+	FileID    string `xml:"fileid,attr"`
+}
+
+func NewOpenCover() *OpenCover {
+	return &OpenCover{}
+}
+
+func (o *OpenCover) Name() string {
+	return "OpenCover"
+}
+
+func (o *OpenCover) ParseReport(path string) (*Coverage, string, error) {
+	rp, err := o.detectReportPath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := os.ReadFile(filepath.Clean(rp))
+	if err != nil {
+		return nil, "", err
+	}
+	r := OpenCoverReport{}
+	if err := xml.Unmarshal(b, &r); err != nil {
+		return nil, "", err
+	}
+	if r.Modules == nil {
+		return nil, "", fmt.Errorf("%s is not OpenCover format", filepath.Clean(rp))
+	}
+
+	cov := New()
+	cov.Type = TypeStmt
+	cov.Format = o.Name()
+
+	type pos struct {
+		sl, sc, el, ec int
+	}
+	type branch struct {
+		sl, offset, offsetEnd, path int
+	}
+	fcovs := map[string]*FileCoverage{}
+	// The same sequence point is reported once per method instance (e.g. generic instantiations).
+	seen := map[string]map[pos]*BlockCoverage{}
+	branches := map[string]map[branch]ExecCount{}
+	for _, m := range r.Modules.Module {
+		// File uids are scoped to the module.
+		files := map[string]string{}
+		for _, f := range m.Files.File {
+			files[f.UID] = f.FullPath
+		}
+		for _, c := range m.Classes.Class {
+			for _, mt := range c.Methods.Method {
+				for _, sp := range mt.SequencePoints.SequencePoint {
+					if sp.Sl == openCoverHiddenLine || sp.Sl <= 0 {
+						continue
+					}
+					uid := sp.FileID
+					if uid == "" && mt.FileRef != nil {
+						uid = mt.FileRef.UID
+					}
+					n, ok := files[uid]
+					if !ok {
+						continue
+					}
+					fcov, ok := fcovs[n]
+					if !ok {
+						fcov = NewFileCoverage(n, TypeStmt)
+						fcovs[n] = fcov
+						seen[n] = map[pos]*BlockCoverage{}
+						cov.Files = append(cov.Files, fcov)
+					}
+					p := pos{sp.Sl, sp.Sc, sp.El, sp.Ec - 1}
+					if p.el < p.sl {
+						p.el = p.sl
+					}
+					if p.sl == p.el && p.ec < p.sc {
+						p.ec = p.sc
+					}
+					c := toExecCount(sp.Vc)
+					if b, ok := seen[n][p]; ok {
+						*b.Count = satAdd(*b.Count, c)
+						continue
+					}
+					ns := 1
+					b := &BlockCoverage{
+						Type:      TypeStmt,
+						StartLine: &p.sl,
+						StartCol:  &p.sc,
+						EndLine:   &p.el,
+						EndCol:    &p.ec,
+						NumStmt:   &ns,
+						Count:     &c,
+					}
+					seen[n][p] = b
+					fcov.Blocks = append(fcov.Blocks, b)
+				}
+				for _, bp := range mt.BranchPoints.BranchPoint {
+					if bp.Sl == openCoverHiddenLine || bp.Sl <= 0 {
+						continue
+					}
+					uid := bp.FileID
+					if uid == "" && mt.FileRef != nil {
+						uid = mt.FileRef.UID
+					}
+					n, ok := files[uid]
+					if !ok {
+						continue
+					}
+					if _, ok := fcovs[n]; !ok {
+						continue
+					}
+					if _, ok := branches[n]; !ok {
+						branches[n] = map[branch]ExecCount{}
+					}
+					k := branch{bp.Sl, bp.Offset, bp.OffsetEnd, bp.Path}
+					branches[n][k] = satAdd(branches[n][k], toExecCount(bp.Vc))
+				}
+			}
+		}
+	}
+
+	for _, fcov := range cov.Files {
+		for _, b := range fcov.Blocks {
+			fcov.Total += *b.NumStmt
+			if *b.Count > 0 {
+				fcov.Covered += *b.NumStmt
+			}
+		}
+		var bc BranchCoverages
+		for k, c := range branches[fcov.File] {
+			covered := 0
+			if c > 0 {
+				covered = 1
+			}
+			bc = bc.addBranches(k.sl, 1, covered)
+		}
+		fcov.setBranches(bc)
+		cov.Total += fcov.Total
+		cov.Covered += fcov.Covered
+		cov.BranchTotal += fcov.BranchTotal
+		cov.BranchCovered += fcov.BranchCovered
+	}
+
+	return cov, rp, nil
+}
+
+func (o *OpenCover) detectReportPath(path string) (string, error) {
+	p, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if p.IsDir() {
+		path = filepath.Join(path, OpenCoverDefaultPath)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
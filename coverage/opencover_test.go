@@ -0,0 +1,146 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenCover(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "opencover")
+	got, _, err := NewOpenCover().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 6; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 4; got.Covered != want {
+		t.Errorf("got %v\nwant %v", got.Covered, want)
+	}
+	if want := 2; len(got.Files) != want {
+		t.Fatalf("got %v\nwant %v", len(got.Files), want)
+	}
+	if want := "/home/runner/work/app/app/src/App/Calc.cs"; got.Files[0].File != want {
+		t.Errorf("got %v\nwant %v", got.Files[0].File, want)
+	}
+
+	for _, f := range got.Files {
+		total := 0
+		covered := 0
+		for _, b := range f.Blocks {
+			// Statement
+			total = total + *b.NumStmt
+			if *b.Count > 0 {
+				covered += *b.NumStmt
+			}
+		}
+		if got := f.Total; got != total {
+			t.Errorf("got %v\nwant %v", got, total)
+		}
+		if got := f.Covered; got != covered {
+			t.Errorf("got %v\nwant %v", got, covered)
+		}
+	}
+}
+
+func TestOpenCoverColumns(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "opencover", "coverage.opencover.xml")
+	got, _, err := NewOpenCover().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fc := got.Files[0]
+
+	// The hidden sequence point (line 0xFEEFEE) is skipped.
+	if want := 4; len(fc.Blocks) != want {
+		t.Fatalf("got %v\nwant %v", len(fc.Blocks), want)
+	}
+
+	// `ec` is exclusive.
+	bs := fc.FindBlocksByLine(7)
+	if want := 1; len(bs) != want {
+		t.Fatalf("got %v\nwant %v", len(bs), want)
+	}
+	if want := 13; *bs[0].StartCol != want {
+		t.Errorf("got %v\nwant %v", *bs[0].StartCol, want)
+	}
+	if want := 22; *bs[0].EndCol != want {
+		t.Errorf("got %v\nwant %v", *bs[0].EndCol, want)
+	}
+}
+
+func TestOpenCoverDuplicateSequencePoints(t *testing.T) {
+	// Generic methods report the same sequence point once per instantiation.
+	report := `<?xml version="1.0" encoding="utf-8"?>
+<CoverageSession>
+  <Modules>
+    <Module>
+      <Files>
+        <File uid="1" fullPath="/src/Box.cs" />
+      </Files>
+      <Classes>
+        <Class>
+          <Methods>
+            <Method>
+              <FileRef uid="1" />
+              <SequencePoints>
+                <SequencePoint vc="2" sl="3" sc="9" el="3" ec="20" />
+              </SequencePoints>
+            </Method>
+            <Method>
+              <FileRef uid="1" />
+              <SequencePoints>
+                <SequencePoint vc="1" sl="3" sc="9" el="3" ec="20" />
+              </SequencePoints>
+            </Method>
+          </Methods>
+        </Class>
+      </Classes>
+    </Module>
+  </Modules>
+</CoverageSession>`
+	p := filepath.Join(t.TempDir(), OpenCoverDefaultPath)
+	if err := os.WriteFile(p, []byte(report), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := NewOpenCover().ParseReport(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 1; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 1; len(got.Files[0].Blocks) != want {
+		t.Fatalf("got %v\nwant %v", len(got.Files[0].Blocks), want)
+	}
+	if want := ExecCount(3); *got.Files[0].Blocks[0].Count != want {
+		t.Errorf("got %v\nwant %v", *got.Files[0].Blocks[0].Count, want)
+	}
+}
+
+func TestOpenCoverParseAllFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{filepath.Join(testdataDir(t), "gocover", "coverage.out"), true},
+		{filepath.Join(testdataDir(t), "lcov", "lcov.info"), true},
+		{filepath.Join(testdataDir(t), "simplecov", ".resultset.json"), true},
+		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "cobertura", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), true},
+		{filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json"), true},
+		{filepath.Join(testdataDir(t), "coveragepy", "coverage.json"), true},
+		{filepath.Join(testdataDir(t), "llvmcov", "coverage.json"), true},
+		{filepath.Join(testdataDir(t), "opencover", "coverage.opencover.xml"), false},
+		{filepath.Join(testdataDir(t), "gcov", "main.gcov.json.gz"), true},
+		{filepath.Join(testdataDir(t), "sonar", "coverage.xml"), true},
+	}
+	for _, tt := range tests {
+		_, _, err := NewOpenCover().ParseReport(tt.path)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+		}
+	}
+}
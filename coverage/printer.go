@@ -5,9 +5,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"html"
 	"io"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/goark/gnkf/enc"
@@ -17,48 +19,105 @@ import (
 const maxSrcSize = 1073741824 //1GB
 
 type Printer struct {
-	fc *FileCoverage
+	fc        *FileCoverage
+	bySuite   bool
+	uncovered bool
+	context   int
 }
 
-func NewPrinter(fc *FileCoverage) *Printer {
-	return &Printer{
+type PrinterOption func(*Printer)
+
+// BySuite makes the Printer show the labels of the test suites executing each line.
+func BySuite(enable bool) PrinterOption {
+	return func(p *Printer) {
+		p.bySuite = enable
+	}
+}
+
+// Uncovered makes the Printer print only the uncovered regions with n lines of context, each preceded by a hunk header.
+func Uncovered(n int) PrinterOption {
+	return func(p *Printer) {
+		p.uncovered = true
+		p.context = max(0, n)
+	}
+}
+
+func NewPrinter(fc *FileCoverage, opts ...PrinterOption) *Printer {
+	p := &Printer{
 		fc: fc,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 func (p *Printer) Print(src io.Reader, dest io.Writer) error {
-	dup := new(bytes.Buffer)
-	size, err := io.CopyN(dup, src, maxSrcSize)
-	if !errors.Is(err, io.EOF) {
+	b, err := readSource(src)
+	if err != nil {
 		return err
 	}
-	if size >= maxSrcSize {
-		return fmt.Errorf("too large file size to copy: %d >= %d", size, maxSrcSize)
-	}
-	b := dup.Bytes()
 	c := bytes.Count(b, []byte{'\n'})
 
 	w := len(strconv.Itoa(c))
 	w2 := len(strconv.FormatUint(uint64(p.fc.Blocks.MaxCount()), 10))
 
-	e, err := guess.EncodingBytes(b)
-	if err != nil {
-		return err
-	}
-	dup2 := new(bytes.Buffer)
-	if err := enc.Convert("UTF-8", dup2, e[0], dup); err != nil {
-		return err
+	lcs := p.fc.ToLineCoverages()
+	il := ParseIgnoredLines(b)
+	var (
+		suites map[int][]string
+		w3     int
+	)
+	if p.bySuite {
+		suites = p.fc.SuitesByLine()
+		for _, labels := range suites {
+			w3 = max(w3, len(strings.Join(labels, ",")))
+		}
 	}
 
-	lcs := p.fc.Blocks.ToLineCoverages()
+	var hunks []hunk
+	if p.uncovered {
+		last := c
+		if len(b) > 0 && b[len(b)-1] != '\n' {
+			last++
+		}
+		hunks = uncoveredHunks(p.fc.UncoveredRanges(), p.context, last)
+	}
 
-	scanner := bufio.NewScanner(dup2)
+	scanner := bufio.NewScanner(bytes.NewReader(b))
 	n := 1
 	cl := color.New(color.FgYellow)
 	cl.EnableColor()
+	hc := color.New(color.FgCyan)
+	hc.EnableColor()
 	for scanner.Scan() {
-		lc, _ := lcs.FindByLine(n) //nostyle:handlerrors
-		c, out := paintLine(n, w2, scanner.Text(), lc)
+		if p.uncovered {
+			for len(hunks) > 0 && hunks[0].end < n {
+				hunks = hunks[1:]
+			}
+			if len(hunks) == 0 {
+				break
+			}
+			if n < hunks[0].start {
+				n += 1
+				continue
+			}
+			if n == hunks[0].start {
+				if _, err := fmt.Fprintln(dest, hc.Sprintf("@@ L%d-L%d @@", hunks[0].start, hunks[0].end)); err != nil {
+					return err
+				}
+			}
+		}
+		var c, out string
+		if il.Contains(n) {
+			c, out = paintIgnoredLine(w2, scanner.Text())
+		} else {
+			lc, _ := lcs.FindByLine(n) //nostyle:handlerrors
+			c, out = paintLine(n, w2, scanner.Text(), lc)
+		}
+		if p.bySuite {
+			out = fmt.Sprintf("%s %s", paintSuites(w3, suites[n]), out)
+		}
 		if _, err := fmt.Fprintf(dest, "%s %s %s\n", cl.Sprint(fmt.Sprintf(fmt.Sprintf("%%%dd", w), n)), c, out); err != nil { //nolint:gosec // dest is a terminal writer, not a web response
 			return err
 		}
@@ -70,6 +129,75 @@ func (p *Printer) Print(src io.Reader, dest io.Writer) error {
 	return nil
 }
 
+// PrintHTML prints the source annotated with the code coverage as an HTML table.
+// Each row has the class of the state of the line (covered, uncovered, partial or ignored) and the id of the line number (e.g. L12).
+func (p *Printer) PrintHTML(src io.Reader, dest io.Writer) error {
+	b, err := readSource(src)
+	if err != nil {
+		return err
+	}
+	lcs := p.fc.ToLineCoverages()
+	il := ParseIgnoredLines(b)
+	var suites map[int][]string
+	if p.bySuite {
+		suites = p.fc.SuitesByLine()
+	}
+
+	if _, err := fmt.Fprintln(dest, `<table class="source">`); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	n := 1
+	for scanner.Scan() {
+		var class, count, code string
+		if il.Contains(n) {
+			class, code = "ignored", html.EscapeString(scanner.Text())
+		} else {
+			lc, _ := lcs.FindByLine(n) //nostyle:handlerrors
+			class, count, code = htmlLine(scanner.Text(), lc)
+		}
+		suite := ""
+		if p.bySuite {
+			suite = fmt.Sprintf(`<td class="suites">%s</td>`, html.EscapeString(strings.Join(suites[n], ",")))
+		}
+		if class != "" {
+			class = fmt.Sprintf(` class="%s"`, class)
+		}
+		if _, err := fmt.Fprintf(dest, `<tr%s id="L%d"><td class="num"><a href="#L%d">%d</a></td>%s<td class="count">%s</td><td class="code">%s</td></tr>`+"\n", class, n, n, n, suite, count, code); err != nil {
+			return err
+		}
+		n += 1
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(dest, `</table>`); err != nil {
+		return err
+	}
+	return nil
+}
+
+// readSource reads the source up to maxSrcSize and converts it to UTF-8.
+func readSource(src io.Reader) ([]byte, error) {
+	dup := new(bytes.Buffer)
+	size, err := io.CopyN(dup, src, maxSrcSize)
+	if !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if size >= maxSrcSize {
+		return nil, fmt.Errorf("too large file size to copy: %d >= %d", size, maxSrcSize)
+	}
+	e, err := guess.EncodingBytes(dup.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	dup2 := new(bytes.Buffer)
+	if err := enc.Convert("UTF-8", dup2, e[0], dup); err != nil {
+		return nil, err
+	}
+	return dup2.Bytes(), nil
+}
+
 const (
 	posGreen = "g"
 	posRed   = "r"
@@ -96,8 +224,12 @@ func lineCovered(lcnt int, lc *LineCoverage) (ExecCount, []string) {
 	return lc.Count, l
 }
 
+// paintLine paints the covered parts of the line in green, the uncovered parts in red, and the covered parts of the partially covered line in yellow.
 func paintLine(n, w int, in string, lc *LineCoverage) (string, string) {
 	g := color.New(color.FgGreen)
+	if lc != nil && lc.Partial {
+		g = color.New(color.FgYellow)
+	}
 	g.EnableColor()
 	r := color.New(color.FgRed)
 	r.EnableColor()
@@ -135,9 +267,76 @@ func paintLine(n, w int, in string, lc *LineCoverage) (string, string) {
 	s := strings.Repeat(" ", w)
 	if c > 0 {
 		g := color.New(color.FgHiGreen)
+		if lc.Partial {
+			g = color.New(color.FgHiYellow)
+		}
 		g.EnableColor()
 		s = g.Sprintf(fmt.Sprintf("%%%dd", w), c)
 	}
 
 	return s, out.String()
 }
+
+// htmlLine returns the class of the state of the line, the count and the HTML of the line.
+// The covered parts of the line are marked with the hit class, and the uncovered parts with the miss class.
+func htmlLine(in string, lc *LineCoverage) (string, string, string) {
+	c, l := lineCovered(len(in), lc)
+	class := ""
+	switch {
+	case lc == nil:
+	case lc.Partial:
+		class = "partial"
+	case c > 0:
+		class = "covered"
+	default:
+		class = "uncovered"
+	}
+
+	var out strings.Builder
+	writeSegment := func(s, pos string) {
+		switch pos {
+		case posGreen:
+			fmt.Fprintf(&out, `<span class="hit">%s</span>`, html.EscapeString(s))
+		case posRed:
+			fmt.Fprintf(&out, `<span class="miss">%s</span>`, html.EscapeString(s))
+		default:
+			out.WriteString(html.EscapeString(s))
+		}
+	}
+	pos := 0
+	current := ""
+	for i, cl := range l {
+		// Segments are split only at the start of characters not to break multibyte characters.
+		if current == cl || !utf8.RuneStart(in[i]) {
+			continue
+		}
+		writeSegment(in[pos:i], current)
+		current = cl
+		pos = i
+	}
+	writeSegment(in[pos:], current)
+
+	count := ""
+	if c > 0 {
+		count = strconv.FormatUint(uint64(c), 10)
+	}
+	return class, count, out.String()
+}
+
+// paintIgnoredLine paints the line ignored by the `octocov:ignore` pragmas.
+func paintIgnoredLine(w int, in string) (string, string) {
+	i := color.New(color.FgHiBlack)
+	i.EnableColor()
+	return strings.Repeat(" ", w), i.Sprint(in)
+}
+
+// paintSuites paints the labels of the test suites executing the line.
+func paintSuites(w int, labels []string) string {
+	c := color.New(color.FgCyan)
+	c.EnableColor()
+	s := fmt.Sprintf("%-*s", w, strings.Join(labels, ","))
+	if len(labels) == 0 {
+		return s
+	}
+	return c.Sprint(s)
+}
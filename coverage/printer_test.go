@@ -2,6 +2,7 @@ package coverage
 
 import (
 	"bytes"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -72,3 +73,178 @@ func IsOK(in string) error {
 		}
 	}
 }
+
+func TestPrintIgnoredLines(t *testing.T) {
+	code := `package coverage
+
+func IsOK(in string) error {
+	if in != "ok" {
+		panic(in) // octocov:ignore
+	}
+	return nil
+}
+`
+	fc := &FileCoverage{
+		Blocks: BlockCoverages{
+			newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 1),
+			newBlockCoverage(TypeLOC, 7, -1, 7, -1, -1, 1),
+		},
+		cache: map[int]BlockCoverages{},
+	}
+	dest := new(bytes.Buffer)
+	if err := NewPrinter(fc).Print(strings.NewReader(code), dest); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(dest.String(), "\n")
+	want := "\x1b[33m5\x1b[0m   \x1b[90m\t\tpanic(in) // octocov:ignore\x1b[0m"
+	if got := lines[4]; got != want {
+		t.Errorf("got\n%v\n%#v\n\nwant\n%v\n%#v", got, got, want, want)
+	}
+}
+
+func TestPrintPartialLines(t *testing.T) {
+	code := `package coverage
+
+func IsOK(in string) error {
+	if in != "ok" {
+		return nil
+	}
+	return nil
+}
+`
+	fc := &FileCoverage{
+		Blocks: BlockCoverages{
+			newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 1),
+			newBlockCoverage(TypeLOC, 7, -1, 7, -1, -1, 1),
+		},
+		Branches: BranchCoverages{
+			&BranchCoverage{Line: 4, Total: 2, Covered: 1},
+		},
+		cache: map[int]BlockCoverages{},
+	}
+	dest := new(bytes.Buffer)
+	if err := NewPrinter(fc).Print(strings.NewReader(code), dest); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(dest.String(), "\n")
+	want := "\x1b[33m4\x1b[0m \x1b[93m1\x1b[0m \x1b[33m\tif in != \"ok\" {\x1b[0m"
+	if got := lines[3]; got != want {
+		t.Errorf("got\n%v\n%#v\n\nwant\n%v\n%#v", got, got, want, want)
+	}
+	want = "\x1b[33m7\x1b[0m \x1b[92m1\x1b[0m \x1b[32m\treturn nil\x1b[0m"
+	if got := lines[6]; got != want {
+		t.Errorf("got\n%v\n%#v\n\nwant\n%v\n%#v", got, got, want, want)
+	}
+}
+
+func TestPrintUncovered(t *testing.T) {
+	code := `package coverage
+
+import "fmt"
+
+func IsOK(in string) error {
+	if in != "ok" {
+		return fmt.Errorf("error: %s", in)
+	}
+	return nil
+}
+`
+	fc := &FileCoverage{
+		Blocks: BlockCoverages{
+			newBlockCoverage(TypeLOC, 6, -1, 6, -1, -1, 1),
+			newBlockCoverage(TypeLOC, 7, -1, 7, -1, -1, 0),
+			newBlockCoverage(TypeLOC, 9, -1, 9, -1, -1, 1),
+		},
+		cache: map[int]BlockCoverages{},
+	}
+	dest := new(bytes.Buffer)
+	if err := NewPrinter(fc, Uncovered(1)).Print(strings.NewReader(code), dest); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"\x1b[36m@@ L6-L8 @@\x1b[0m",
+		"\x1b[33m 6\x1b[0m \x1b[92m1\x1b[0m \x1b[32m\tif in != \"ok\" {\x1b[0m",
+		"\x1b[33m 7\x1b[0m   \x1b[31m\t\treturn fmt.Errorf(\"error: %s\", in)\x1b[0m",
+		"\x1b[33m 8\x1b[0m   \t}",
+		"",
+	}
+	if got := strings.Split(dest.String(), "\n"); !slices.Equal(got, want) {
+		t.Errorf("got\n%#v\n\nwant\n%#v", got, want)
+	}
+}
+
+func TestPrintHTML(t *testing.T) {
+	code := `package coverage
+
+func IsOK(in string) error {
+	if in != "<ok>" {
+		panic(in) // octocov:ignore
+	}
+	return nil
+}
+`
+	fc := &FileCoverage{
+		Blocks: BlockCoverages{
+			newBlockCoverage(TypeStmt, 3, 28, 4, 17, 1, 1),
+			newBlockCoverage(TypeStmt, 4, 17, 6, 3, 1, 0),
+			newBlockCoverage(TypeStmt, 7, 2, 7, 12, 1, 0),
+		},
+		cache: map[int]BlockCoverages{},
+	}
+	dest := new(bytes.Buffer)
+	if err := NewPrinter(fc).PrintHTML(strings.NewReader(code), dest); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(dest.String(), "\n")
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{0, `<table class="source">`},
+		{1, `<tr id="L1"><td class="num"><a href="#L1">1</a></td><td class="count"></td><td class="code">package coverage</td></tr>`},
+		{4, `<tr class="partial" id="L4"><td class="num"><a href="#L4">4</a></td><td class="count">1</td><td class="code"><span class="hit">	if in != &#34;&lt;ok&gt;&#34; </span><span class="miss">{</span></td></tr>`},
+		{5, `<tr class="ignored" id="L5"><td class="num"><a href="#L5">5</a></td><td class="count"></td><td class="code">		panic(in) // octocov:ignore</td></tr>`},
+		{7, `<tr class="uncovered" id="L7"><td class="num"><a href="#L7">7</a></td><td class="count"></td><td class="code">	<span class="miss">return nil</span></td></tr>`},
+		{9, `</table>`},
+	}
+	for _, tt := range tests {
+		if got := lines[tt.n]; got != tt.want {
+			t.Errorf("got\n%v\n\nwant\n%v", got, tt.want)
+		}
+	}
+}
+
+func TestPrintBySuite(t *testing.T) {
+	code := `package coverage
+
+func IsOK(in string) bool {
+	return in == "ok"
+}
+`
+	fc := &FileCoverage{
+		Blocks: BlockCoverages{
+			newLabeledBlockCoverage(TypeLOC, 4, 4, -1, 1, "unit"),
+			newLabeledBlockCoverage(TypeLOC, 4, 4, -1, 1, "e2e"),
+			newLabeledBlockCoverage(TypeLOC, 3, 3, -1, 1, "e2e"),
+		},
+		cache: map[int]BlockCoverages{},
+	}
+	dest := new(bytes.Buffer)
+	if err := NewPrinter(fc, BySuite(true)).Print(strings.NewReader(code), dest); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(dest.String(), "\n")
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{1, "\x1b[33m1\x1b[0m            package coverage"},
+		{3, "\x1b[33m3\x1b[0m \x1b[92m1\x1b[0m \x1b[36me2e     \x1b[0m \x1b[32mfunc IsOK(in string) bool {\x1b[0m"},
+		{4, "\x1b[33m4\x1b[0m \x1b[92m2\x1b[0m \x1b[36me2e,unit\x1b[0m \x1b[32m\treturn in == \"ok\"\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := lines[tt.n-1]; got != tt.want {
+			t.Errorf("got\n%v\n%#v\n\nwant\n%v\n%#v", got, got, tt.want, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package coverage
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type registeredProcessor struct {
+	key       string
+	processor Processor
+}
+
+var (
+	registryMu sync.RWMutex
+	// registry holds the processors in the order they are tried when the format of a coverage report is detected.
+	registry = []registeredProcessor{
+		{"gocover", NewGocover()},
+		{"lcov", NewLcov()},
+		{"istanbul", NewIstanbul()},
+		{"coveragepy", NewCoveragePy()},
+		{"llvmcov", NewLLVMCov()},
+		{"simplecov", NewSimplecov()},
+		{"clover", NewClover()},
+		{"cobertura", NewCobertura()},
+		{"jacoco", NewJacoco()},
+		{"sonar", NewSonar()},
+		{"opencover", NewOpenCover()},
+		{"gcov", NewGcov()},
+	}
+	// formatAliases are the other names accepted by LookupProcessor.
+	formatAliases = map[string]string{
+		"go": "gocover",
+	}
+)
+
+// Register registers a processor under the format key.
+// Registered processors are tried after the built-in ones when the format of a coverage report is detected,
+// and can be selected explicitly with the key.
+// The processor must be safe for concurrent use as several reports are parsed at the same time.
+func Register(key string, p Processor) error {
+	if key == "" || p == nil {
+		return fmt.Errorf("invalid processor: %q", key)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, rp := range registry {
+		if strings.EqualFold(rp.key, key) {
+			return fmt.Errorf("processor already registered: %s", key)
+		}
+	}
+	registry = append(registry, registeredProcessor{key: key, processor: p})
+	return nil
+}
+
+// Processors returns the registered processors in the order of detection.
+func Processors() []Processor {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	ps := make([]Processor, 0, len(registry))
+	for _, rp := range registry {
+		ps = append(ps, rp.processor)
+	}
+	return ps
+}
+
+// Formats returns the format keys of the registered processors in the order of detection.
+func Formats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	keys := make([]string, 0, len(registry))
+	for _, rp := range registry {
+		keys = append(keys, rp.key)
+	}
+	return keys
+}
+
+// LookupProcessor returns the processor of the format.
+// The format is matched case-insensitively against the keys and the names of the processors (e.g. `jacoco`, `JaCoCo`).
+func LookupProcessor(format string) (Processor, error) {
+	f := strings.ToLower(format)
+	if a, ok := formatAliases[f]; ok {
+		f = a
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, rp := range registry {
+		if strings.EqualFold(rp.key, f) || strings.EqualFold(rp.processor.Name(), f) {
+			return rp.processor, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported coverage format: %s", format)
+}
+
+// unregister removes the processor registered under the format key.
+func unregister(key string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for i, rp := range registry {
+		if strings.EqualFold(rp.key, key) {
+			registry = append(registry[:i:i], registry[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLookupProcessor(t *testing.T) {
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{"jacoco", "JaCoCo", false},
+		{"JaCoCo", "JaCoCo", false},
+		{"go", "Go coverage", false},
+		{"gocover", "Go coverage", false},
+		{"coverage.py", "coverage.py", false},
+		{"coveragepy", "coverage.py", false},
+		{"llvm-cov", "llvm-cov", false},
+		{"unknown", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := LookupProcessor(tt.format)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("got %v\nwantErr %v", nil, tt.wantErr)
+			}
+			if got.Name() != tt.want {
+				t.Errorf("got %v\nwant %v", got.Name(), tt.want)
+			}
+		})
+	}
+}
+
+type testProcessor struct{}
+
+func (p *testProcessor) Name() string {
+	return "test"
+}
+
+func (p *testProcessor) ParseReport(path string) (*Coverage, string, error) {
+	cov := New()
+	cov.Format = p.Name()
+	return cov, path, nil
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register("test-register", &testProcessor{}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		unregister("test-register")
+	})
+	if err := Register("test-register", &testProcessor{}); err == nil {
+		t.Error("want error")
+	}
+	if err := Register("lcov", &testProcessor{}); err == nil {
+		t.Error("want error")
+	}
+	ps := Processors()
+	if got := ps[len(ps)-1].Name(); got != "test" {
+		t.Errorf("got %v\nwant %v", got, "test")
+	}
+	formats := Formats()
+	if got := formats[0]; got != "gocover" {
+		t.Errorf("got %v\nwant %v", got, "gocover")
+	}
+	p, err := LookupProcessor("test-register")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cov, _, err := p.ParseReport(filepath.Join(testdataDir(t), "lcov", "lcov.info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cov.Format != "test" {
+		t.Errorf("got %v\nwant %v", cov.Format, "test")
+	}
+}
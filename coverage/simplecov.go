@@ -2,8 +2,10 @@ package coverage
 
 import (
 	"errors"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/goccy/go-json"
 )
@@ -71,8 +73,10 @@ func (s *Simplecov) ParseReport(path string) (*Coverage, string, error) {
 	cov.Format = s.Name()
 	fcovs := map[string]*FileCoverage{}
 	sls := skipLines{}
-	for _, c := range r {
-		for fn, fc := range c.Coverage {
+	for _, k := range slices.Sorted(maps.Keys(r)) {
+		c := r[k]
+		for _, fn := range slices.Sorted(maps.Keys(c.Coverage)) {
+			fc := c.Coverage[fn]
 			fcov, ok := fcovs[fn]
 			if !ok {
 				fcov = NewFileCoverage(fn, TypeLOC)
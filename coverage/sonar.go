@@ -0,0 +1,113 @@
+package coverage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var _ Processor = (*Sonar)(nil)
+
+const SonarDefaultPath = "coverage.xml"
+
+const sonarGenericCoverageVersion = "1"
+
+type Sonar struct{}
+
+// SonarReport is the generic test coverage format of SonarQube.
+// ref: https://docs.sonarsource.com/sonarqube/latest/analyzing-source-code/test-coverage/generic-test-data/
+type SonarReport struct {
+	XMLName xml.Name          `xml:"coverage"`
+	Version string            `xml:"version,attr"`
+	File    []SonarReportFile `xml:"file"`
+}
+
+type SonarReportFile struct {
+	Path        string `xml:"path,attr"`
+	LineToCover []struct {
+		LineNumber      int  `xml:"lineNumber,attr"`
+		Covered         bool `xml:"covered,attr"`
+		BranchesToCover int  `xml:"branchesToCover,attr"`
+		CoveredBranches int  `xml:"coveredBranches,attr"`
+	} `xml:"lineToCover"`
+}
+
+func NewSonar() *Sonar {
+	return &Sonar{}
+}
+
+func (s *Sonar) Name() string {
+	return "Sonar"
+}
+
+func (s *Sonar) ParseReport(path string) (*Coverage, string, error) {
+	rp, err := s.detectReportPath(path)
+	if err != nil {
+		return nil, "", err
+	}
+	b, err := os.ReadFile(filepath.Clean(rp))
+	if err != nil {
+		return nil, "", err
+	}
+	r := SonarReport{}
+	if err := xml.Unmarshal(b, &r); err != nil {
+		return nil, "", err
+	}
+	if r.Version != sonarGenericCoverageVersion {
+		return nil, "", fmt.Errorf("%s is not Sonar generic coverage format", filepath.Clean(rp))
+	}
+
+	cov := New()
+	cov.Type = TypeLOC
+	cov.Format = s.Name()
+	for _, f := range r.File {
+		fcov, err := cov.Files.FindByFile(f.Path)
+		if err != nil {
+			fcov = NewFileCoverage(f.Path, TypeLOC)
+			cov.Files = append(cov.Files, fcov)
+		}
+		for _, l := range f.LineToCover {
+			sl := l.LineNumber
+			el := l.LineNumber
+			// The generic format does not record execution counts.
+			var c ExecCount
+			if l.Covered {
+				c = 1
+			}
+			fcov.Blocks = append(fcov.Blocks, &BlockCoverage{
+				Type:      TypeLOC,
+				StartLine: &sl,
+				EndLine:   &el,
+				Count:     &c,
+			})
+			fcov.Branches = fcov.Branches.addBranches(l.LineNumber, l.BranchesToCover, l.CoveredBranches)
+		}
+	}
+	for _, fcov := range cov.Files {
+		lcs := fcov.Blocks.ToLineCoverages()
+		fcov.Total = lcs.Total()
+		fcov.Covered = lcs.Covered()
+		fcov.setBranches(fcov.Branches)
+		cov.Total += fcov.Total
+		cov.Covered += fcov.Covered
+		cov.BranchTotal += fcov.BranchTotal
+		cov.BranchCovered += fcov.BranchCovered
+	}
+
+	return cov, rp, nil
+}
+
+func (s *Sonar) detectReportPath(path string) (string, error) {
+	p, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if p.IsDir() {
+		path = filepath.Join(path, SonarDefaultPath)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
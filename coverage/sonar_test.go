@@ -0,0 +1,81 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSonar(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "sonar")
+	got, _, err := NewSonar().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 6; got.Total != want {
+		t.Errorf("got %v\nwant %v", got.Total, want)
+	}
+	if want := 3; got.Covered != want {
+		t.Errorf("got %v\nwant %v", got.Covered, want)
+	}
+	if want := 2; len(got.Files) != want {
+		t.Fatalf("got %v\nwant %v", len(got.Files), want)
+	}
+	if want := "src/main/java/com/example/Calc.java"; got.Files[0].File != want {
+		t.Errorf("got %v\nwant %v", got.Files[0].File, want)
+	}
+	wantBranches := []BranchCoverages{
+		{{Line: 6, Total: 2, Covered: 1}},
+		{{Line: 4, Total: 2, Covered: 0}},
+	}
+	for i, f := range got.Files {
+		if diff := cmp.Diff(f.Branches, wantBranches[i]); diff != "" {
+			t.Errorf("%s: %s", f.File, diff)
+		}
+	}
+
+	for _, f := range got.Files {
+		total := 0
+		covered := 0
+		for _, b := range f.Blocks {
+			// LOC
+			total += 1
+			if *b.Count > 0 {
+				covered += 1
+			}
+		}
+		if got := f.Total; got != total {
+			t.Errorf("got %v\nwant %v", got, total)
+		}
+		if got := f.Covered; got != covered {
+			t.Errorf("got %v\nwant %v", got, covered)
+		}
+	}
+}
+
+func TestSonarParseAllFormat(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{filepath.Join(testdataDir(t), "gocover", "coverage.out"), true},
+		{filepath.Join(testdataDir(t), "lcov", "lcov.info"), true},
+		{filepath.Join(testdataDir(t), "simplecov", ".resultset.json"), true},
+		{filepath.Join(testdataDir(t), "clover", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "cobertura", "coverage.xml"), true},
+		{filepath.Join(testdataDir(t), "jacoco", "jacocoTestReport.xml"), true},
+		{filepath.Join(testdataDir(t), "istanbul", "coverage", "coverage-final.json"), true},
+		{filepath.Join(testdataDir(t), "coveragepy", "coverage.json"), true},
+		{filepath.Join(testdataDir(t), "llvmcov", "coverage.json"), true},
+		{filepath.Join(testdataDir(t), "opencover", "coverage.opencover.xml"), true},
+		{filepath.Join(testdataDir(t), "gcov", "main.gcov.json.gz"), true},
+		{filepath.Join(testdataDir(t), "sonar", "coverage.xml"), false},
+	}
+	for _, tt := range tests {
+		_, _, err := NewSonar().ParseReport(tt.path)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("got %v\nwantErr %v", err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package coverage
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// StreamProcessor is a Processor that can hand over the coverage of each file as soon as it is parsed,
+// so that a large report does not have to be held in memory as a whole.
+type StreamProcessor interface {
+	Processor
+	// StreamReport calls fn with the coverage of each file in the report in the order they appear.
+	// The same file may be passed more than once.
+	StreamReport(path string, fn func(fc *FileCoverage) error) (string, error)
+}
+
+// Builder builds a Coverage from coverages and file coverages added one by one.
+// Files are looked up by path with an index, so adding many files stays linear.
+type Builder struct {
+	cov            *Coverage
+	files          map[string]*FileCoverage
+	aggregateLines bool
+	label          string
+	root           string
+	idx            suffixIndex
+	dirty          bool
+}
+
+type BuilderOption func(*Builder)
+
+// AggregateLines makes the Builder keep only the per-line counts of each file (see FileCoverage.AggregateLines).
+func AggregateLines(enable bool) BuilderOption {
+	return func(b *Builder) {
+		b.aggregateLines = enable
+	}
+}
+
+// Label makes the Builder attribute the counts of the blocks added to the test suite of the label.
+func Label(label string) BuilderOption {
+	return func(b *Builder) {
+		b.label = label
+	}
+}
+
+// NormalizeWith makes the Builder set NormalizedPath of each file before adding it (see Coverage.NormalizePaths).
+func NormalizeWith(root string, fsFiles []string) BuilderOption {
+	return func(b *Builder) {
+		if root == "" || len(fsFiles) == 0 {
+			return
+		}
+		b.root = filepath.Clean(root)
+		b.idx = buildSuffixIndex(fsFiles)
+	}
+}
+
+func NewBuilder(opts ...BuilderOption) *Builder {
+	b := &Builder{
+		files: map[string]*FileCoverage{},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func newBuilderFrom(c *Coverage) *Builder {
+	b := NewBuilder()
+	b.cov = c
+	for _, fc := range c.Files {
+		b.index(fc)
+	}
+	return b
+}
+
+// Add merges the coverage into the coverage being built.
+// The first coverage added is adopted as it is.
+func (b *Builder) Add(c *Coverage) {
+	if c == nil {
+		return
+	}
+	for _, fc := range c.Files {
+		b.normalize(fc)
+		if b.label != "" {
+			fc.SetLabel(b.label)
+		}
+		if b.aggregateLines {
+			fc.AggregateLines()
+		}
+	}
+	if b.aggregateLines {
+		if c.Type == TypeStmt {
+			c.Type = TypeLOC
+		}
+		b.dirty = true
+	}
+	if b.cov == nil {
+		b.cov = c
+		for _, fc := range c.Files {
+			b.index(fc)
+		}
+		return
+	}
+	b.mergeType(c.Type, c.Format)
+	for _, fc := range c.Files {
+		b.merge(fc)
+	}
+	b.dirty = true
+}
+
+// AddFile merges the file coverage parsed from a report of the format into the coverage being built.
+func (b *Builder) AddFile(format string, fc *FileCoverage) {
+	b.normalize(fc)
+	if b.label != "" {
+		fc.SetLabel(b.label)
+	}
+	if b.aggregateLines {
+		fc.AggregateLines()
+	}
+	if b.cov == nil {
+		b.cov = New()
+		b.cov.Type = fc.Type
+		b.cov.Format = format
+	} else {
+		b.mergeType(fc.Type, format)
+	}
+	if b.merge(fc) {
+		b.dirty = true
+		return
+	}
+	// A new file is simply added up unless the coverage has to be recalculated anyway.
+	c := b.cov
+	c.Total += fc.Total
+	c.Covered += fc.Covered
+	c.BranchTotal += fc.BranchTotal
+	c.BranchCovered += fc.BranchCovered
+	c.FunctionTotal += fc.FunctionTotal
+	c.FunctionCovered += fc.FunctionCovered
+}
+
+// Coverage returns the coverage built. It returns nil if nothing has been added.
+func (b *Builder) Coverage() (*Coverage, error) {
+	if b.cov == nil {
+		return nil, nil
+	}
+	if b.dirty {
+		if err := b.cov.reCalc(); err != nil {
+			return nil, err
+		}
+		b.dirty = false
+	}
+	return b.cov, nil
+}
+
+func (b *Builder) mergeType(t Type, format string) {
+	c := b.cov
+	// Type
+	switch {
+	case t == "":
+	case c.Type != TypeLOC || t != TypeLOC:
+		// If either is not LOC, merge as Merged
+		if c.Type != TypeMerged {
+			// Files measured by statements are counted by lines from now on.
+			b.dirty = true
+		}
+		c.Type = TypeMerged
+	}
+	// Format
+	if format != "" {
+		if c.Format == "" {
+			c.Format = format
+		} else if c.Format != format {
+			c.Format = FormatMerged
+		}
+	}
+}
+
+// merge adds the file coverage, or merges it into the file of the same path. It reports whether it was merged.
+func (b *Builder) merge(fc2 *FileCoverage) bool {
+	fc, ok := b.files[fc2.EffectivePath()]
+	if !ok && fc2.EffectivePath() != fc2.File {
+		fc, ok = b.files[fc2.File]
+	}
+	if !ok {
+		b.cov.Files = append(b.cov.Files, fc2)
+		b.index(fc2)
+		return false
+	}
+	if fc2.Type != fc.Type {
+		fc.Type = TypeMerged
+	}
+	// Merged coverage should be counted as LOC as duplicate blocks may be stacked.
+	fc.Blocks = append(fc.Blocks, fc2.Blocks...)
+	fc.Branches = fc.Branches.merge(fc2.Branches)
+	fc.Functions = fc.Functions.merge(fc2.Functions)
+	fc.cache = nil
+	if b.aggregateLines {
+		fc.AggregateLines()
+	}
+	return true
+}
+
+// index registers the paths of the file. The file added first wins as FileCoverages.FindByFile does.
+func (b *Builder) index(fc *FileCoverage) {
+	for _, k := range []string{fc.EffectivePath(), fc.File} {
+		if _, ok := b.files[k]; !ok {
+			b.files[k] = fc
+		}
+	}
+}
+
+func (b *Builder) normalize(fc *FileCoverage) {
+	if b.idx == nil {
+		return
+	}
+	fc.NormalizedPath = normalizeSingle(b.root, fc.File, b.idx)
+}
+
+// AggregateLines replaces the blocks of the file with one block per line holding the count of the line.
+// Columns and statement counts are dropped, so the file is measured by lines afterwards.
+// Total and Covered are updated accordingly.
+// The counts of the test suites are kept apart by the labels of the blocks.
+func (fc *FileCoverage) AggregateLines() {
+	lcs := fc.Blocks.lineCounts()
+	covered := 0
+	for _, lc := range lcs {
+		if lc.count > 0 {
+			covered++
+		}
+	}
+	labels := fc.Blocks.labels()
+	switch len(labels) {
+	case 0:
+		fc.Blocks = lineBlocks(lcs, "")
+	case 1:
+		fc.Blocks = lineBlocks(lcs, labels[0])
+	default:
+		var blocks BlockCoverages
+		for _, l := range labels {
+			blocks = append(blocks, lineBlocks(fc.Blocks.withLabel(l).lineCounts(), l)...)
+		}
+		fc.Blocks = blocks
+	}
+	fc.Total = len(lcs)
+	fc.Covered = covered
+	if fc.Type != TypeMerged {
+		fc.Type = TypeLOC
+	}
+	fc.cache = nil
+}
+
+// lineBlocks returns the blocks of the lines labeled with the test suite.
+func lineBlocks(lcs []lineCount, label string) BlockCoverages {
+	s := newBlockSlab(len(lcs))
+	blocks := make(BlockCoverages, 0, len(lcs))
+	for _, lc := range lcs {
+		b := s.lineBlock(lc.line, lc.count)
+		b.Label = label
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+type lineCount struct {
+	line  int
+	count ExecCount
+}
+
+// lineCounts returns the count of each line in order of lines.
+// Blocks of single lines (e.g. LCOV) are summed up directly without building the positions of ToLineCoverages.
+func (bc BlockCoverages) lineCounts() []lineCount { //nostyle:recvtype
+	simple := true
+	for _, b := range bc {
+		if b.Type != TypeLOC || *b.StartLine != *b.EndLine {
+			simple = false
+			break
+		}
+	}
+	if !simple {
+		lcs := bc.ToLineCoverages()
+		counts := make([]lineCount, 0, len(lcs))
+		for _, lc := range lcs {
+			counts = append(counts, lineCount{line: lc.Line, count: lc.Count})
+		}
+		return counts
+	}
+	counts := make([]lineCount, 0, len(bc))
+	for _, b := range bc {
+		counts = append(counts, lineCount{line: *b.StartLine, count: *b.Count})
+	}
+	sort.SliceStable(counts, func(i, j int) bool {
+		return counts[i].line < counts[j].line
+	})
+	merged := counts[:0]
+	for _, lc := range counts {
+		if n := len(merged); n > 0 && merged[n-1].line == lc.line {
+			merged[n-1].count = satAdd(merged[n-1].count, lc.count)
+			continue
+		}
+		merged = append(merged, lc)
+	}
+	return merged
+}
+
+// blockSlab allocates line blocks and their fields from shared arrays
+// instead of allocating each block, line and count separately.
+type blockSlab struct {
+	blocks     []BlockCoverage
+	startLines []int
+	endLines   []int
+	counts     []ExecCount
+}
+
+const blockSlabChunk = 1024
+
+func newBlockSlab(n int) *blockSlab {
+	s := &blockSlab{}
+	s.grow(n)
+	return s
+}
+
+func (s *blockSlab) grow(n int) {
+	s.blocks = make([]BlockCoverage, 0, n)
+	s.startLines = make([]int, 0, n)
+	s.endLines = make([]int, 0, n)
+	s.counts = make([]ExecCount, 0, n)
+}
+
+// lineBlock returns a TypeLOC block of the line.
+func (s *blockSlab) lineBlock(line int, count ExecCount) *BlockCoverage {
+	if len(s.blocks) == cap(s.blocks) {
+		// Earlier arrays stay alive through the blocks pointing into them.
+		s.grow(blockSlabChunk)
+	}
+	s.startLines = append(s.startLines, line)
+	s.endLines = append(s.endLines, line)
+	s.counts = append(s.counts, count)
+	s.blocks = append(s.blocks, BlockCoverage{
+		Type:      TypeLOC,
+		StartLine: &s.startLines[len(s.startLines)-1],
+		EndLine:   &s.endLines[len(s.endLines)-1],
+		Count:     &s.counts[len(s.counts)-1],
+	})
+	return &s.blocks[len(s.blocks)-1]
+}
@@ -0,0 +1,128 @@
+package coverage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLcovStreamReport(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "lcov_branch", "branch.lcov")
+	var files []string
+	if _, err := NewLcov().StreamReport(path, func(fc *FileCoverage) error {
+		files = append(files, fc.File)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"src/calc.js", "src/noop.js"}
+	if diff := cmp.Diff(files, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCoberturaStreamReport(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "cobertura", "coverage.xml")
+	want, _, err := NewCobertura().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	b := NewBuilder()
+	if _, err := NewCobertura().StreamReport(path, func(fc *FileCoverage) error {
+		n++
+		b.AddFile("Cobertura", fc)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		t.Fatal("no files are streamed")
+	}
+	got, err := b.Coverage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != want.Total || got.Covered != want.Covered {
+		t.Errorf("got %v/%v\nwant %v/%v", got.Covered, got.Total, want.Covered, want.Total)
+	}
+}
+
+func TestLineBlockLines(t *testing.T) {
+	s := newBlockSlab(1)
+	b := s.lineBlock(3, 1)
+	*b.EndLine = 5
+	if *b.StartLine != 3 {
+		t.Errorf("got %v\nwant %v", *b.StartLine, 3)
+	}
+}
+
+func TestAggregateLines(t *testing.T) {
+	path := filepath.Join(testdataDir(t), "gocover", "coverage.out")
+	cov, _, err := NewGocover().ParseReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fc := range cov.Files {
+		want := fc.Blocks.ToLineCoverages()
+		fc.AggregateLines()
+		if fc.Type != TypeLOC {
+			t.Errorf("got %v\nwant %v", fc.Type, TypeLOC)
+		}
+		got := fc.Blocks.ToLineCoverages()
+		if len(got) != len(want) || len(fc.Blocks) != len(want) {
+			t.Fatalf("got %v\nwant %v", len(got), len(want))
+		}
+		for i := range want {
+			if got[i].Line != want[i].Line || got[i].Count != want[i].Count {
+				t.Errorf("got %v:%v\nwant %v:%v", got[i].Line, got[i].Count, want[i].Line, want[i].Count)
+			}
+		}
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	newFile := func(counts ...int) *FileCoverage {
+		fc := NewFileCoverage("file_a.js", TypeLOC)
+		for i, c := range counts {
+			fc.Blocks = append(fc.Blocks, newBlockCoverage(TypeLOC, i+1, -1, i+1, -1, -1, c))
+		}
+		return fc
+	}
+	tests := []struct {
+		name           string
+		aggregateLines bool
+		wantBlocks     int
+	}{
+		{"stack blocks", false, 6},
+		{"aggregate lines", true, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBuilder(AggregateLines(tt.aggregateLines))
+			b.AddFile("LCOV", newFile(1, 0, 0))
+			b.AddFile("LCOV", newFile(2, 1, 0))
+			got, err := b.Coverage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Files) != 1 {
+				t.Fatalf("got %v\nwant %v", len(got.Files), 1)
+			}
+			if got.Type != TypeLOC {
+				t.Errorf("got %v\nwant %v", got.Type, TypeLOC)
+			}
+			if got.Total != 3 || got.Covered != 2 {
+				t.Errorf("got %v/%v\nwant %v/%v", got.Covered, got.Total, 2, 3)
+			}
+			if len(got.Files[0].Blocks) != tt.wantBlocks {
+				t.Errorf("got %v\nwant %v", len(got.Files[0].Blocks), tt.wantBlocks)
+			}
+			lcs := got.Files[0].Blocks.ToLineCoverages()
+			if lcs[0].Count != 3 {
+				t.Errorf("got %v\nwant %v", lcs[0].Count, 3)
+			}
+		})
+	}
+}
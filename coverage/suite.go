@@ -0,0 +1,108 @@
+package coverage
+
+import (
+	"slices"
+)
+
+// SetLabel attributes the counts of the blocks of the coverage to the test suite of the label.
+func (c *Coverage) SetLabel(label string) {
+	if c == nil {
+		return
+	}
+	for _, fc := range c.Files {
+		fc.SetLabel(label)
+	}
+}
+
+// Suites returns the labels of the test suites the blocks are attributed to, sorted.
+// Blocks without a label are not attributed to any test suite.
+func (c *Coverage) Suites() []string {
+	if c == nil {
+		return nil
+	}
+	var labels []string
+	for _, fc := range c.Files {
+		for _, l := range fc.Blocks.labels() {
+			if l != "" && !slices.Contains(labels, l) {
+				labels = append(labels, l)
+			}
+		}
+	}
+	slices.Sort(labels)
+	return labels
+}
+
+// SuiteCovered returns the number of the lines covered by the test suite,
+// or the number of the statements for the coverage of statements.
+func (c *Coverage) SuiteCovered(label string) int {
+	if c == nil {
+		return 0
+	}
+	covered := 0
+	for _, fc := range c.Files {
+		blocks := fc.Blocks.withLabel(label)
+		if c.Type == TypeStmt {
+			for _, b := range blocks {
+				if *b.Count > 0 && b.NumStmt != nil {
+					covered += *b.NumStmt
+				}
+			}
+			continue
+		}
+		for _, lc := range blocks.lineCounts() {
+			if lc.count > 0 {
+				covered++
+			}
+		}
+	}
+	return covered
+}
+
+// SetLabel attributes the counts of the blocks of the file to the test suite of the label.
+func (fc *FileCoverage) SetLabel(label string) {
+	for _, b := range fc.Blocks {
+		b.Label = label
+	}
+}
+
+// SuitesByLine returns the labels of the test suites executing each line of the file, sorted.
+func (fc *FileCoverage) SuitesByLine() map[int][]string {
+	suites := map[int][]string{}
+	for _, l := range fc.Blocks.labels() {
+		if l == "" {
+			continue
+		}
+		for _, lc := range fc.Blocks.withLabel(l).lineCounts() {
+			if lc.count > 0 {
+				suites[lc.line] = append(suites[lc.line], l)
+			}
+		}
+	}
+	return suites
+}
+
+// labels returns the labels of the blocks, sorted.
+func (bc BlockCoverages) labels() []string { //nostyle:recvtype
+	var labels []string
+	for _, b := range bc {
+		if !slices.Contains(labels, b.Label) {
+			labels = append(labels, b.Label)
+		}
+	}
+	slices.Sort(labels)
+	return labels
+}
+
+// withLabel returns the blocks attributed to the test suite of the label.
+func (bc BlockCoverages) withLabel(label string) BlockCoverages { //nostyle:recvtype
+	if !slices.ContainsFunc(bc, func(b *BlockCoverage) bool { return b.Label != label }) {
+		return bc
+	}
+	var blocks BlockCoverages
+	for _, b := range bc {
+		if b.Label == label {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks
+}
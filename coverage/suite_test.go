@@ -0,0 +1,119 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newLabeledBlockCoverage(t Type, sl, el, ns, c int, label string) *BlockCoverage {
+	b := newBlockCoverage(t, sl, -1, el, -1, ns, c)
+	b.Label = label
+	return b
+}
+
+func TestSuites(t *testing.T) {
+	c := &Coverage{
+		Type: TypeMerged,
+		Files: FileCoverages{
+			&FileCoverage{
+				File: "a.go",
+				Type: TypeMerged,
+				Blocks: BlockCoverages{
+					newLabeledBlockCoverage(TypeLOC, 1, 1, -1, 1, "unit"),
+					newLabeledBlockCoverage(TypeLOC, 2, 2, -1, 0, "unit"),
+					newLabeledBlockCoverage(TypeLOC, 3, 3, -1, 2, "unit"),
+					newLabeledBlockCoverage(TypeLOC, 1, 1, -1, 0, "e2e"),
+					newLabeledBlockCoverage(TypeLOC, 2, 2, -1, 1, "e2e"),
+					newLabeledBlockCoverage(TypeLOC, 3, 3, -1, 1, "e2e"),
+				},
+			},
+			&FileCoverage{
+				File: "b.go",
+				Type: TypeLOC,
+				Blocks: BlockCoverages{
+					newLabeledBlockCoverage(TypeLOC, 1, 1, -1, 1, "e2e"),
+					newBlockCoverage(TypeLOC, 2, -1, 2, -1, -1, 1),
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff([]string{"e2e", "unit"}, c.Suites()); diff != "" {
+		t.Error(diff)
+	}
+	if got, want := c.SuiteCovered("unit"), 2; got != want {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+	if got, want := c.SuiteCovered("e2e"), 3; got != want {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+	want := map[int][]string{
+		1: {"unit"},
+		2: {"e2e"},
+		3: {"e2e", "unit"},
+	}
+	if diff := cmp.Diff(want, c.Files[0].SuitesByLine()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestSuiteCoveredStmt(t *testing.T) {
+	c := &Coverage{
+		Type: TypeStmt,
+		Files: FileCoverages{
+			&FileCoverage{
+				File: "a.go",
+				Type: TypeStmt,
+				Blocks: BlockCoverages{
+					newLabeledBlockCoverage(TypeStmt, 1, 3, 2, 1, "unit"),
+					newLabeledBlockCoverage(TypeStmt, 4, 5, 3, 0, "unit"),
+				},
+			},
+		},
+	}
+	if got, want := c.SuiteCovered("unit"), 2; got != want {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+}
+
+func TestAggregateLinesWithLabels(t *testing.T) {
+	fc := &FileCoverage{
+		Type: TypeLOC,
+		Blocks: BlockCoverages{
+			newLabeledBlockCoverage(TypeLOC, 1, 2, -1, 1, "unit"),
+			newLabeledBlockCoverage(TypeLOC, 2, 3, -1, 0, "e2e"),
+			newLabeledBlockCoverage(TypeLOC, 3, 3, -1, 2, "e2e"),
+		},
+	}
+	fc.AggregateLines()
+	if got, want := fc.Total, 3; got != want {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+	if got, want := fc.Covered, 3; got != want {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+	want := map[int][]string{
+		1: {"unit"},
+		2: {"unit"},
+		3: {"e2e"},
+	}
+	if diff := cmp.Diff(want, fc.SuitesByLine()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestBuilderLabel(t *testing.T) {
+	b := NewBuilder(Label("unit"))
+	b.AddFile("lcov", &FileCoverage{
+		File:   "a.go",
+		Type:   TypeLOC,
+		Blocks: BlockCoverages{newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1)},
+	})
+	c, err := b.Coverage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"unit"}, c.Suites()); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,20 @@
+package calc
+
+type Calc struct{}
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func (c *Calc) Abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func Unused() {
+	println("unused")
+}
+
+var _ = func() int { return 0 }
@@ -0,0 +1,59 @@
+package coverage
+
+// UncoveredRange is a range of the lines not covered.
+// The range may contain lines without coverage data (e.g. blank lines or comments) between the uncovered lines.
+type UncoveredRange struct {
+	StartLine int   `json:"start_line"`
+	EndLine   int   `json:"end_line"`
+	Lines     []int `json:"lines"`
+}
+
+// UncoveredRanges returns the ranges of the lines not covered in order of lines.
+func (fc *FileCoverage) UncoveredRanges() []*UncoveredRange {
+	if fc == nil {
+		return nil
+	}
+	var (
+		ranges  []*UncoveredRange
+		current *UncoveredRange
+	)
+	for _, lc := range fc.ToLineCoverages() {
+		if lc.Count > 0 {
+			current = nil
+			continue
+		}
+		if current == nil {
+			current = &UncoveredRange{StartLine: lc.Line}
+			ranges = append(ranges, current)
+		}
+		current.EndLine = lc.Line
+		current.Lines = append(current.Lines, lc.Line)
+	}
+	return ranges
+}
+
+// hunk is a range of lines printed around the uncovered lines.
+type hunk struct {
+	start int
+	end   int
+}
+
+// uncoveredHunks returns the hunks of the uncovered lines of the ranges with n lines of context, up to the last line.
+// Lines without coverage data in a range are not printed beyond the context. Overlapping or adjacent hunks are merged.
+func uncoveredHunks(ranges []*UncoveredRange, n, last int) []hunk {
+	var hunks []hunk
+	for _, r := range ranges {
+		for _, l := range r.Lines {
+			h := hunk{start: max(1, l-n), end: min(last, l+n)}
+			if h.start > h.end {
+				continue
+			}
+			if len(hunks) > 0 && hunks[len(hunks)-1].end+1 >= h.start {
+				hunks[len(hunks)-1].end = max(hunks[len(hunks)-1].end, h.end)
+				continue
+			}
+			hunks = append(hunks, h)
+		}
+	}
+	return hunks
+}
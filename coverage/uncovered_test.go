@@ -0,0 +1,54 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUncoveredRanges(t *testing.T) {
+	fc := &FileCoverage{
+		Blocks: BlockCoverages{
+			newBlockCoverage(TypeLOC, 1, -1, 1, -1, -1, 1),
+			newBlockCoverage(TypeLOC, 2, -1, 2, -1, -1, 0),
+			// Line 3 has no coverage data.
+			newBlockCoverage(TypeLOC, 4, -1, 4, -1, -1, 0),
+			newBlockCoverage(TypeLOC, 5, -1, 5, -1, -1, 2),
+			newBlockCoverage(TypeLOC, 8, -1, 8, -1, -1, 0),
+		},
+	}
+	got := fc.UncoveredRanges()
+	want := []*UncoveredRange{
+		{StartLine: 2, EndLine: 4, Lines: []int{2, 4}},
+		{StartLine: 8, EndLine: 8, Lines: []int{8}},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestUncoveredHunks(t *testing.T) {
+	ranges := []*UncoveredRange{
+		{StartLine: 2, EndLine: 2, Lines: []int{2}},
+		{StartLine: 6, EndLine: 7, Lines: []int{6, 7}},
+		{StartLine: 20, EndLine: 21, Lines: []int{20, 21}},
+		// Lines without coverage data (e.g. a long comment) lie between the uncovered lines.
+		{StartLine: 40, EndLine: 100, Lines: []int{40, 100}},
+	}
+	tests := []struct {
+		n    int
+		last int
+		want []hunk
+	}{
+		{0, 200, []hunk{{2, 2}, {6, 7}, {20, 21}, {40, 40}, {100, 100}}},
+		{1, 200, []hunk{{1, 3}, {5, 8}, {19, 22}, {39, 41}, {99, 101}}},
+		{2, 200, []hunk{{1, 9}, {18, 23}, {38, 42}, {98, 102}}},
+		{2, 20, []hunk{{1, 9}, {18, 20}}},
+	}
+	for _, tt := range tests {
+		got := uncoveredHunks(ranges, tt.n, tt.last)
+		if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(hunk{})); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
@@ -38,6 +38,8 @@ type ReportRecord struct {
 	Commit              string               `bigquery:"commit"`
 	CoverageTotal       bigquery.NullInt64   `bigquery:"coverage_total"`
 	CoverageCovered     bigquery.NullInt64   `bigquery:"coverage_covered"`
+	FunctionTotal       bigquery.NullInt64   `bigquery:"function_total"`
+	FunctionCovered     bigquery.NullInt64   `bigquery:"function_covered"`
 	CodeToTestRatioCode bigquery.NullInt64   `bigquery:"code_to_test_ratio_code"`
 	CodeToTestRatioTest bigquery.NullInt64   `bigquery:"code_to_test_ratio_test"`
 	TestExecutionTime   bigquery.NullFloat64 `bigquery:"test_execution_time"`
@@ -53,6 +55,8 @@ var reportsSchema = bigquery.Schema{
 	&bigquery.FieldSchema{Name: "commit", Type: bigquery.StringFieldType, Required: true},
 	&bigquery.FieldSchema{Name: "coverage_total", Type: bigquery.IntegerFieldType, Required: false},
 	&bigquery.FieldSchema{Name: "coverage_covered", Type: bigquery.IntegerFieldType, Required: false},
+	&bigquery.FieldSchema{Name: "function_total", Type: bigquery.IntegerFieldType, Required: false},
+	&bigquery.FieldSchema{Name: "function_covered", Type: bigquery.IntegerFieldType, Required: false},
 	&bigquery.FieldSchema{Name: "code_to_test_ratio_code", Type: bigquery.IntegerFieldType, Required: false},
 	&bigquery.FieldSchema{Name: "code_to_test_ratio_test", Type: bigquery.IntegerFieldType, Required: false},
 	&bigquery.FieldSchema{Name: "test_execution_time", Type: bigquery.NumericFieldType, Required: false},
@@ -90,6 +94,16 @@ func (b *BQ) StoreReport(ctx context.Context, r *report.Report) error {
 			Valid: true,
 		}
 	}
+	if r.IsMeasuredFunctionCoverage() {
+		rr.FunctionTotal = bigquery.NullInt64{
+			Int64: int64(r.Coverage.FunctionTotal),
+			Valid: true,
+		}
+		rr.FunctionCovered = bigquery.NullInt64{
+			Int64: int64(r.Coverage.FunctionCovered),
+			Valid: true,
+		}
+	}
 	if r.CodeToTestRatio != nil {
 		rr.CodeToTestRatioCode = bigquery.NullInt64{
 			Int64: int64(r.CodeToTestRatio.Code),
@@ -36,6 +36,9 @@ const maxCopySize = 1073741824 // 1GB
 
 var octocovNameRe = regexp.MustCompile(`(?i)(octocov|coverage)`)
 
+// hunkHeaderRe matches the header of a unified diff hunk and captures the start lines and the line counts of the old and new files.
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
 type Gh struct {
 	client   *github.Client
 	v4Client *githubv4.Client
@@ -318,6 +321,39 @@ type PullRequestFile struct {
 	Filename string
 	BlobURL  string
 	Status   string
+	// ChangedLines are the lines added or changed in the file, parsed from the diff hunks.
+	// It is nil if the diff is not available (e.g. binary or too large files).
+	ChangedLines []int
+	hunks        []hunk
+}
+
+// hunk is a hunk of a unified diff.
+type hunk struct {
+	oldStart int
+	oldLines int
+	newLines int
+	// oldToNew maps the lines of the old file kept in the hunk to the lines of the new file.
+	oldToNew map[int]int
+}
+
+// MapOldLine returns the line of the file after the change that corresponds to the line before the change.
+// It returns false if the line is removed by the change or the diff of the file is not available.
+func (f *PullRequestFile) MapOldLine(line int) (int, bool) {
+	if f.hunks == nil {
+		return 0, false
+	}
+	delta := 0
+	for _, h := range f.hunks {
+		if line < h.oldStart {
+			break
+		}
+		if line < h.oldStart+h.oldLines {
+			n, ok := h.oldToNew[line]
+			return n, ok
+		}
+		delta += h.newLines - h.oldLines
+	}
+	return line + delta, true
 }
 
 func (g *Gh) FetchPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*PullRequestFile, error) {
@@ -336,9 +372,11 @@ func (g *Gh) FetchPullRequestFiles(ctx context.Context, owner, repo string, numb
 		}
 		for _, f := range commitFiles {
 			files = append(files, &PullRequestFile{
-				Filename: f.GetFilename(),
-				BlobURL:  f.GetBlobURL(),
-				Status:   f.GetStatus(),
+				Filename:     f.GetFilename(),
+				BlobURL:      f.GetBlobURL(),
+				Status:       f.GetStatus(),
+				ChangedLines: parseChangedLines(f.GetPatch()),
+				hunks:        parseHunks(f.GetPatch()),
 			})
 		}
 		page += 1
@@ -362,13 +400,90 @@ func (g *Gh) FetchChangedFiles(ctx context.Context, owner, repo string) ([]*Pull
 	var files []*PullRequestFile
 	for _, f := range compare.Files {
 		files = append(files, &PullRequestFile{
-			Filename: f.GetFilename(),
-			BlobURL:  f.GetBlobURL(),
+			Filename:     f.GetFilename(),
+			BlobURL:      f.GetBlobURL(),
+			ChangedLines: parseChangedLines(f.GetPatch()),
+			hunks:        parseHunks(f.GetPatch()),
 		})
 	}
 	return files, nil
 }
 
+// parseChangedLines returns the lines added or changed in the new file of the unified diff hunks.
+func parseChangedLines(patch string) []int {
+	if patch == "" {
+		return nil
+	}
+	lines := []int{}
+	n := 0
+	for l := range strings.Lines(patch) {
+		l = strings.TrimRight(l, "\r\n")
+		if m := hunkHeaderRe.FindStringSubmatch(l); m != nil {
+			n, _ = strconv.Atoi(m[3])
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(l, "+"):
+			lines = append(lines, n)
+			n++
+		case strings.HasPrefix(l, "-"), strings.HasPrefix(l, "\\"):
+			// Removed lines and "\ No newline at end of file" are not in the new file.
+		default:
+			n++
+		}
+	}
+	return lines
+}
+
+// parseHunks returns the hunks of the unified diff in order of lines.
+func parseHunks(patch string) []hunk {
+	if patch == "" {
+		return nil
+	}
+	hunks := []hunk{}
+	var o, n int
+	for l := range strings.Lines(patch) {
+		l = strings.TrimRight(l, "\r\n")
+		if m := hunkHeaderRe.FindStringSubmatch(l); m != nil {
+			h := hunk{oldLines: 1, newLines: 1, oldToNew: map[int]int{}}
+			h.oldStart, _ = strconv.Atoi(m[1])
+			if m[2] != "" {
+				h.oldLines, _ = strconv.Atoi(m[2])
+			}
+			n, _ = strconv.Atoi(m[3])
+			if m[4] != "" {
+				h.newLines, _ = strconv.Atoi(m[4])
+			}
+			o = h.oldStart
+			if h.oldLines == 0 {
+				// The lines are inserted after the start line of the old file.
+				h.oldStart++
+			}
+			hunks = append(hunks, h)
+			continue
+		}
+		if len(hunks) == 0 {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(l, "+"):
+			n++
+		case strings.HasPrefix(l, "-"):
+			o++
+		case strings.HasPrefix(l, "\\"):
+			// "\ No newline at end of file" is not a line.
+		default:
+			hunks[len(hunks)-1].oldToNew[o] = n
+			o++
+			n++
+		}
+	}
+	return hunks
+}
+
 func (g *Gh) FetchStepExecutionTimeByTime(ctx context.Context, owner, repo string, jobID int64, t time.Time) (time.Duration, error) {
 	p := backoff.Exponential( //nostyle:funcfmt
 		backoff.WithMinInterval(time.Second),
@@ -629,3 +629,63 @@ func TestIsSameRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestParseChangedLines(t *testing.T) {
+	tests := []struct {
+		patch string
+		want  []int
+	}{
+		{"", nil},
+		{
+			"@@ -1,3 +1,4 @@\n package main\n+import \"fmt\"\n \n func main() {}\n",
+			[]int{2},
+		},
+		{
+			"@@ -10,4 +10,4 @@ func a() {\n \tx := 1\n-\ty := 2\n+\ty := 3\n \treturn\n }\n@@ -30,2 +30,3 @@ func b() {\n \tz := 1\n+\tw := 2\n+\tv := 3\n\\ No newline at end of file\n",
+			[]int{11, 31, 32},
+		},
+		{
+			"@@ -1,2 +0,0 @@\n-a\n-b\n",
+			[]int{},
+		},
+		{
+			"@@ -0,0 +1 @@\n+a\r\n",
+			[]int{1},
+		},
+	}
+	for _, tt := range tests {
+		got := parseChangedLines(tt.patch)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
+
+func TestMapOldLine(t *testing.T) {
+	patch := "@@ -2,3 +2,3 @@ func a() {\n \tx := 1\n-\ty := 2\n+\ty := 3\n \treturn\n@@ -10,0 +11,2 @@\n+\tz := 1\n+\tw := 2\n@@ -20,2 +22 @@\n-\tv := 1\n \treturn\n\\ No newline at end of file\n"
+	f := &PullRequestFile{hunks: parseHunks(patch)}
+	tests := []struct {
+		line   int
+		want   int
+		wantOK bool
+	}{
+		{1, 1, true},
+		{2, 2, true},
+		{3, 0, false},
+		{4, 4, true},
+		{10, 10, true},
+		{11, 13, true},
+		{20, 0, false},
+		{21, 22, true},
+		{30, 31, true},
+	}
+	for _, tt := range tests {
+		got, ok := f.MapOldLine(tt.line)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("line %d: got %v, %v\nwant %v, %v", tt.line, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if _, ok := (&PullRequestFile{}).MapOldLine(1); ok {
+		t.Error("got true\nwant false without the diff")
+	}
+}
@@ -0,0 +1,301 @@
+package html
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/k1LoW/octocov/coverage"
+	"github.com/k1LoW/octocov/report"
+)
+
+//go:embed index.html.tmpl
+var indexTmpl []byte
+
+//go:embed file.html.tmpl
+var fileTmpl []byte
+
+//go:embed style.css
+var style []byte
+
+const (
+	indexFile = "index.html"
+	styleFile = "style.css"
+	filesDir  = "files"
+)
+
+// Site is the static HTML site of the code coverage: an index page with the directory tree and a page per file.
+type Site struct {
+	config *Config
+	report *report.Report
+}
+
+type Config struct {
+	// Root is the root directory of the repository to find the source files in.
+	Root string
+	// Wd is the working directory to find the source files in when they are not found in Root.
+	Wd            string
+	BySuite       bool
+	CoverageColor func(cover float64) string
+}
+
+// entry is a row of the directory tree in the index page.
+type entry struct {
+	Name    string
+	Link    string
+	Depth   int
+	IsDir   bool
+	Total   int
+	Covered int
+	Partial int
+}
+
+func (e *entry) Percent() float64 {
+	if e.Total == 0 {
+		return 0.0
+	}
+	return float64(e.Covered) / float64(e.Total) * 100
+}
+
+func New(r *report.Report, c *Config) *Site {
+	return &Site{
+		config: c,
+		report: r,
+	}
+}
+
+// Generate generates the site in dir and returns the paths of the generated files.
+func (s *Site) Generate(dir string) ([]string, error) {
+	if s.report.Coverage == nil {
+		return nil, fmt.Errorf("no coverage: %s", s.report.Repository)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil { //nolint:gosec
+		return nil, err
+	}
+	var paths []string
+	sp := filepath.Join(dir, styleFile)
+	if err := os.WriteFile(sp, style, 0644); err != nil { //nolint:gosec
+		return nil, err
+	}
+	paths = append(paths, sp)
+
+	files := make(coverage.FileCoverages, len(s.report.Coverage.Files))
+	copy(files, s.report.Coverage.Files)
+	sort.SliceStable(files, func(i, j int) bool {
+		return displayName(files[i]) < displayName(files[j])
+	})
+	partials := map[*coverage.FileCoverage]int{}
+	pages := pagePaths(files)
+	for _, fc := range files {
+		partials[fc] = fc.ToLineCoverages().Partial()
+		p := filepath.Join(dir, filepath.FromSlash(pages[fc]))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil { //nolint:gosec
+			return nil, err
+		}
+		if err := s.writeFile(p, pages[fc], fc, partials[fc]); err != nil {
+			return nil, err
+		}
+		paths = append(paths, p)
+	}
+
+	ip := filepath.Join(dir, indexFile)
+	if err := writeTo(ip, func(w io.Writer) error {
+		return s.renderIndex(w, files, pages, partials)
+	}); err != nil {
+		return nil, err
+	}
+	paths = append(paths, ip)
+
+	return paths, nil
+}
+
+func (s *Site) renderIndex(wr io.Writer, files coverage.FileCoverages, pages map[*coverage.FileCoverage]string, partials map[*coverage.FileCoverage]int) error {
+	tmpl := template.Must(template.New("index").Funcs(s.funcs()).Parse(string(indexTmpl)))
+	total := &entry{
+		Total:   s.report.Coverage.Total,
+		Covered: s.report.Coverage.Covered,
+	}
+	for _, p := range partials {
+		total.Partial += p
+	}
+	d := map[string]any{
+		"Report":  s.report,
+		"Total":   total,
+		"Entries": entries(files, pages, partials),
+		"Style":   styleFile,
+	}
+	return tmpl.Execute(wr, d)
+}
+
+func (s *Site) writeFile(p, page string, fc *coverage.FileCoverage, partial int) error {
+	tmpl := template.Must(template.New("file").Funcs(s.funcs()).Parse(string(fileTmpl)))
+	// A source that cannot be read (e.g. too large or in an unknown encoding) does not stop generating the other pages.
+	readErr := ""
+	src, found, err := s.printSource(fc)
+	if err != nil {
+		readErr = err.Error()
+	}
+	up := strings.Repeat("../", strings.Count(page, "/"))
+	d := map[string]any{
+		"Report": s.report,
+		"Name":   displayName(fc),
+		"File": &entry{
+			Total:   fc.Total,
+			Covered: fc.Covered,
+			Partial: partial,
+		},
+		"Found":     found,
+		"ReadError": readErr,
+		"Source":    template.HTML(src), //nolint:gosec // the source is escaped by coverage.Printer
+		"Index":     up + indexFile,
+		"Style":     up + styleFile,
+	}
+	return writeTo(p, func(w io.Writer) error {
+		return tmpl.Execute(w, d)
+	})
+}
+
+// printSource returns the source of the file annotated with the code coverage, and whether the source is found.
+func (s *Site) printSource(fc *coverage.FileCoverage) (string, bool, error) {
+	sp, ok := s.sourcePath(fc)
+	if !ok {
+		return "", false, nil
+	}
+	f, err := os.Open(filepath.Clean(sp))
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+	buf := new(bytes.Buffer)
+	if err := coverage.NewPrinter(fc, coverage.BySuite(s.config.BySuite)).PrintHTML(f, buf); err != nil {
+		return "", false, err
+	}
+	return buf.String(), true, nil
+}
+
+// sourcePath returns the path of the source file of the file coverage.
+func (s *Site) sourcePath(fc *coverage.FileCoverage) (string, bool) {
+	p := filepath.FromSlash(fc.EffectivePath())
+	candidates := []string{p}
+	if !filepath.IsAbs(p) {
+		candidates = nil
+		for _, root := range []string{s.config.Root, s.config.Wd} {
+			if root != "" {
+				candidates = append(candidates, filepath.Join(root, p))
+			}
+		}
+	}
+	for _, c := range candidates {
+		if fi, err := os.Stat(c); err == nil && !fi.IsDir() {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+func (s *Site) funcs() template.FuncMap {
+	return template.FuncMap{
+		"percent": func(e *entry) string {
+			return fmt.Sprintf("%.1f%%", floor1(e.Percent()))
+		},
+		"indent": func(e *entry) string {
+			return fmt.Sprintf("%.1fem", 0.8+1.5*float64(e.Depth))
+		},
+		"color": func(e *entry) string {
+			if s.config.CoverageColor == nil {
+				return ""
+			}
+			return s.config.CoverageColor(e.Percent())
+		},
+	}
+}
+
+// entries returns the rows of the directory tree of the sorted files.
+func entries(files coverage.FileCoverages, pages map[*coverage.FileCoverage]string, partials map[*coverage.FileCoverage]int) []*entry {
+	dirs := map[string]*entry{}
+	for _, fc := range files {
+		parts := strings.Split(displayName(fc), "/")
+		for i := 1; i < len(parts); i++ {
+			d := strings.Join(parts[:i], "/")
+			e, ok := dirs[d]
+			if !ok {
+				e = &entry{Name: parts[i-1] + "/", Depth: i - 1, IsDir: true}
+				dirs[d] = e
+			}
+			e.Total += fc.Total
+			e.Covered += fc.Covered
+			e.Partial += partials[fc]
+		}
+	}
+	var (
+		es   []*entry
+		prev []string
+	)
+	for _, fc := range files {
+		parts := strings.Split(displayName(fc), "/")
+		dir := parts[:len(parts)-1]
+		common := 0
+		for common < len(dir) && common < len(prev) && dir[common] == prev[common] {
+			common++
+		}
+		for i := common; i < len(dir); i++ {
+			es = append(es, dirs[strings.Join(dir[:i+1], "/")])
+		}
+		prev = dir
+		es = append(es, &entry{
+			Name:    parts[len(parts)-1],
+			Link:    pages[fc],
+			Depth:   len(dir),
+			Total:   fc.Total,
+			Covered: fc.Covered,
+			Partial: partials[fc],
+		})
+	}
+	return es
+}
+
+// displayName returns the slash-separated path of the file without the leading slash and parent directories.
+func displayName(fc *coverage.FileCoverage) string {
+	return strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(fc.EffectivePath())), "/")
+}
+
+// pagePaths returns the slash-separated paths of the pages of the files relative to the index page.
+// The files having the same name are given numbered pages not to overwrite each other.
+func pagePaths(files coverage.FileCoverages) map[*coverage.FileCoverage]string {
+	pages := map[*coverage.FileCoverage]string{}
+	used := map[string]bool{}
+	for _, fc := range files {
+		base := path.Join(filesDir, displayName(fc))
+		p := base + ".html"
+		for i := 2; used[p]; i++ {
+			p = fmt.Sprintf("%s.%d.html", base, i)
+		}
+		used[p] = true
+		pages[fc] = p
+	}
+	return pages
+}
+
+func writeTo(p string, render func(w io.Writer) error) error {
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644) // #nosec
+	if err != nil {
+		return err
+	}
+	if err := render(f); err != nil {
+		_ = f.Close() //nostyle:handlerrors
+		return err
+	}
+	return f.Close()
+}
+
+func floor1(v float64) float64 {
+	return math.Floor(v*10) / 10
+}
@@ -0,0 +1,138 @@
+package html
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k1LoW/octocov/config"
+	"github.com/k1LoW/octocov/coverage"
+	"github.com/k1LoW/octocov/report"
+)
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "app", "main.go"), []byte("package main\n\nfunc main() {\n\tprintln(\"<main>\")\n}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// A line longer than the buffer of the scanner of the printer.
+	if err := os.MkdirAll(filepath.Join(root, "gen"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "gen", "long.go"), []byte("var s = \""+strings.Repeat("a", 70*1024)+"\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	newFile := func(file string, covered int, blocks ...*coverage.BlockCoverage) *coverage.FileCoverage {
+		fc := coverage.NewFileCoverage(file, coverage.TypeLOC)
+		fc.Blocks = blocks
+		fc.Total = len(blocks)
+		fc.Covered = covered
+		return fc
+	}
+	newBlock := func(line, count int) *coverage.BlockCoverage {
+		c := coverage.ExecCount(count)
+		return &coverage.BlockCoverage{Type: coverage.TypeLOC, StartLine: &line, EndLine: &line, Count: &c}
+	}
+	r := &report.Report{
+		Repository: "owner/repo",
+		Coverage: &coverage.Coverage{
+			Type:    coverage.TypeLOC,
+			Total:   4,
+			Covered: 3,
+			Files: coverage.FileCoverages{
+				newFile("app/main.go", 1, newBlock(3, 1), newBlock(4, 0)),
+				newFile("gen/long.go", 1, newBlock(1, 1)),
+				newFile("lib/util.go", 1, newBlock(1, 1)),
+				newFile("/abs/lib/util.go", 1, newBlock(1, 1)),
+			},
+		},
+	}
+	c := config.New()
+	out := filepath.Join(t.TempDir(), "out")
+	paths, err := New(r, &Config{
+		Root:          root,
+		CoverageColor: c.CoverageColor,
+	}).Generate(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(out, "index.html"); paths[len(paths)-1] != want {
+		t.Errorf("got %v\nwant %v", paths[len(paths)-1], want)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{
+			"index.html",
+			[]string{
+				"<title>owner/repo - Code Coverage</title>",
+				`<td class="number" style="color: #A4A61D">75.0%</td>`,
+				`<td style="padding-left: 0.8em">app/</td><td class="number" style="color: #DFB317">50.0%</td><td class="number">1 / 2</td>`,
+				`<td style="padding-left: 2.3em"><a href="files/app/main.go.html">main.go</a></td>`,
+				`<a href="files/lib/util.go.html">util.go</a>`,
+				`<a href="files/abs/lib/util.go.html">util.go</a>`,
+			},
+		},
+		{
+			"files/app/main.go.html",
+			[]string{
+				`<link rel="stylesheet" href="../../style.css">`,
+				`<a href="../../index.html">owner/repo</a>`,
+				`<tr class="covered" id="L3">`,
+				`<tr class="uncovered" id="L4"><td class="num"><a href="#L4">4</a></td><td class="count"></td><td class="code"><span class="miss">	println(&#34;&lt;main&gt;&#34;)</span></td></tr>`,
+			},
+		},
+		{
+			"files/gen/long.go.html",
+			[]string{
+				"<p>The source file is not readable (",
+			},
+		},
+		{
+			"files/lib/util.go.html",
+			[]string{
+				"<p>The source file is not found.</p>",
+			},
+		},
+		{
+			"style.css",
+			[]string{
+				".source",
+			},
+		},
+	}
+	for _, tt := range tests {
+		b, err := os.ReadFile(filepath.Join(out, tt.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range tt.want {
+			if !strings.Contains(string(b), w) {
+				t.Errorf("%s: got\n%s\nwant to contain %q", tt.path, b, w)
+			}
+		}
+	}
+}
+
+func TestPagePaths(t *testing.T) {
+	a := coverage.NewFileCoverage("lib/util.go", coverage.TypeLOC)
+	b := coverage.NewFileCoverage("./lib/util.go", coverage.TypeLOC)
+	c := coverage.NewFileCoverage("../lib/util.go", coverage.TypeLOC)
+	got := pagePaths(coverage.FileCoverages{a, b, c})
+	want := map[*coverage.FileCoverage]string{
+		a: "files/lib/util.go.html",
+		b: "files/lib/util.go.2.html",
+		c: "files/lib/util.go.3.html",
+	}
+	for fc, w := range want {
+		if got[fc] != w {
+			t.Errorf("%s: got %v\nwant %v", fc.File, got[fc], w)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package ratio
+
+import (
+	"sync"
+
+	"github.com/hhatto/gocloc"
+)
+
+var definedLanguages = sync.OnceValue(gocloc.NewDefinedLanguages)
+
+// CodeLines returns the numbers of the lines of code in the file, classified by gocloc.
+// It returns false if the language of the file is not detected or not supported.
+func CodeLines(path string) ([]int, bool) {
+	ext, ok := getFileType(path)
+	if !ok {
+		return nil, false
+	}
+	l, ok := gocloc.Exts[ext]
+	if !ok {
+		return nil, false
+	}
+	var (
+		lines []int
+		n     int
+	)
+	opts := gocloc.NewClocOptions()
+	// Each line of the file triggers one of the callbacks in order.
+	opts.OnBlank = func(string) { n++ }
+	opts.OnComment = func(string) { n++ }
+	opts.OnCode = func(string) {
+		n++
+		lines = append(lines, n)
+	}
+	gocloc.AnalyzeFile(path, definedLanguages().Langs[l], opts)
+	return lines, true
+}
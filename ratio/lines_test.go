@@ -0,0 +1,38 @@
+package ratio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCodeLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		want   []int
+		wantOK bool
+	}{
+		{"main.go", "package main\n\n// main does nothing.\nfunc main() {\n\t/* noop */\n}\n", []int{1, 4, 6}, true},
+		{"app.py", "# comment\nimport os\n\n\"\"\"\ndocstring\n\"\"\"\nprint(os.name)\n", []int{2, 7}, true},
+		{"empty.go", "", nil, true},
+		{"README", "hello\n", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := filepath.Join(t.TempDir(), tt.name)
+			if err := os.WriteFile(p, []byte(tt.src), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, ok := CodeLines(p)
+			if ok != tt.wantOK {
+				t.Errorf("got %v\nwant %v", ok, tt.wantOK)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package report
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ComponentCoverage is the code coverage of a named group of files.
+type ComponentCoverage struct {
+	Name    string `json:"name"`
+	Total   int    `json:"total"`
+	Covered int    `json:"covered"`
+}
+
+type DiffComponentCoverage struct {
+	Name       string             `json:"name"`
+	A          float64            `json:"a"`
+	B          *float64           `json:"b"`
+	Diff       float64            `json:"diff"`
+	ComponentA *ComponentCoverage `json:"-"`
+	ComponentB *ComponentCoverage `json:"-"`
+}
+
+func (c *ComponentCoverage) Percent() float64 {
+	if c == nil || c.Total == 0 {
+		return 0.0
+	}
+	return float64(c.Covered) / float64(c.Total) * 100
+}
+
+// Title returns the title of the component used in tables and badges.
+func (c *ComponentCoverage) Title() string {
+	return componentTitle(c.Name)
+}
+
+func (c *ComponentCoverage) Compare(c2 *ComponentCoverage) *DiffComponentCoverage {
+	d := &DiffComponentCoverage{
+		Name:       c.Name,
+		A:          c.Percent(),
+		ComponentA: c,
+		ComponentB: c2,
+	}
+	if c2 != nil {
+		b := c2.Percent()
+		d.B = &b
+	}
+	d.Diff = d.A
+	if d.B != nil {
+		d.Diff = d.A - *d.B
+	}
+	return d
+}
+
+// MeasureComponentCoverage measures the code coverage of the files matching the patterns as the component.
+func (r *Report) MeasureComponentCoverage(name string, patterns []string) error {
+	if r.Coverage == nil {
+		return nil
+	}
+	files, err := r.Coverage.Files.Select(patterns)
+	if err != nil {
+		return fmt.Errorf("component %s: %w", name, err)
+	}
+	cc := &ComponentCoverage{Name: name}
+	for _, f := range files {
+		cc.Total += f.Total
+		cc.Covered += f.Covered
+	}
+	r.Components = slices.DeleteFunc(r.Components, func(c *ComponentCoverage) bool {
+		return c.Name == name
+	})
+	r.Components = append(r.Components, cc)
+	return nil
+}
+
+// IsMeasuredComponentCoverage reports whether the component contains files.
+func (r *Report) IsMeasuredComponentCoverage(name string) bool {
+	c := r.findComponentByName(name)
+	return c != nil && c.Total > 0
+}
+
+func (r *Report) ComponentCoveragePercent(name string) float64 {
+	if r == nil {
+		return 0.0
+	}
+	return r.findComponentByName(name).Percent()
+}
+
+func (r *Report) measureComponents(components map[string][]string) error {
+	for _, name := range slices.Sorted(maps.Keys(components)) {
+		if err := r.MeasureComponentCoverage(name, components[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Report) findComponentByName(name string) *ComponentCoverage {
+	if r == nil {
+		return nil
+	}
+	for _, c := range r.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func componentTitle(name string) string {
+	return fmt.Sprintf("Coverage (%s)", name)
+}
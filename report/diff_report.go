@@ -18,20 +18,23 @@ import (
 )
 
 type DiffReport struct {
-	RepositoryA       string                 `json:"repository_a"`
-	RepositoryB       string                 `json:"repository_b"`
-	RefA              string                 `json:"ref_a"`
-	RefB              string                 `json:"ref_b"`
-	CommitA           string                 `json:"commit_a"`
-	CommitB           string                 `json:"commit_b"`
-	Coverage          *coverage.DiffCoverage `json:"coverage,omitempty"`
-	CodeToTestRatio   *ratio.DiffRatio       `json:"code_to_test_ratio,omitempty"`
-	TestExecutionTime *DiffTestExecutionTime `json:"test_execution_time,omitempty"`
-	CustomMetrics     []*DiffCustomMetricSet `json:"custom_metrics,omitempty"`
-	TimestampA        time.Time              `json:"timestamp_a"`
-	TimestampB        time.Time              `json:"timestamp_b"`
-	ReportA           *Report                `json:"-"`
-	ReportB           *Report                `json:"-"`
+	RepositoryA       string                   `json:"repository_a"`
+	RepositoryB       string                   `json:"repository_b"`
+	RefA              string                   `json:"ref_a"`
+	RefB              string                   `json:"ref_b"`
+	CommitA           string                   `json:"commit_a"`
+	CommitB           string                   `json:"commit_b"`
+	Coverage          *coverage.DiffCoverage   `json:"coverage,omitempty"`
+	CodeToTestRatio   *ratio.DiffRatio         `json:"code_to_test_ratio,omitempty"`
+	TestExecutionTime *DiffTestExecutionTime   `json:"test_execution_time,omitempty"`
+	CustomMetrics     []*DiffCustomMetricSet   `json:"custom_metrics,omitempty"`
+	Suites            []*DiffSuiteCoverage     `json:"suites,omitempty"`
+	Components        []*DiffComponentCoverage `json:"components,omitempty"`
+	Patch             *PatchCoverage           `json:"patch,omitempty"`
+	TimestampA        time.Time                `json:"timestamp_a"`
+	TimestampB        time.Time                `json:"timestamp_b"`
+	ReportA           *Report                  `json:"-"`
+	ReportB           *Report                  `json:"-"`
 }
 
 type DiffTestExecutionTime struct {
@@ -94,6 +97,16 @@ func (d *DiffReport) Table() string {
 		} else if d.Coverage.Diff < 0 {
 			t2 = strings.Replace(t2, "  | Coverage", "- | Coverage", 1)
 		}
+		if d.Coverage.BranchDiff > 0 {
+			t2 = strings.Replace(t2, "  | Branch Coverage", "+ | Branch Coverage", 1)
+		} else if d.Coverage.BranchDiff < 0 {
+			t2 = strings.Replace(t2, "  | Branch Coverage", "- | Branch Coverage", 1)
+		}
+		if d.Coverage.FunctionDiff > 0 {
+			t2 = strings.Replace(t2, "  | Function Coverage", "+ | Function Coverage", 1)
+		} else if d.Coverage.FunctionDiff < 0 {
+			t2 = strings.Replace(t2, "  | Function Coverage", "- | Function Coverage", 1)
+		}
 		if d.Coverage.CoverageA != nil && d.Coverage.CoverageB != nil {
 			if d.Coverage.CoverageA.Covered < d.Coverage.CoverageB.Covered {
 				t2 = strings.Replace(t2, "  |   Covered", "- |   Covered", 1)
@@ -102,6 +115,22 @@ func (d *DiffReport) Table() string {
 			}
 		}
 	}
+	for _, s := range d.Suites {
+		t := fmt.Sprintf("  | %s ", suiteTitle(s.Label))
+		if s.Diff > 0 {
+			t2 = strings.Replace(t2, t, "+"+strings.TrimPrefix(t, " "), 1)
+		} else if s.Diff < 0 {
+			t2 = strings.Replace(t2, t, "-"+strings.TrimPrefix(t, " "), 1)
+		}
+	}
+	for _, c := range d.Components {
+		t := fmt.Sprintf("  | %s ", componentTitle(c.Name))
+		if c.Diff > 0 {
+			t2 = strings.Replace(t2, t, "+"+strings.TrimPrefix(t, " "), 1)
+		} else if c.Diff < 0 {
+			t2 = strings.Replace(t2, t, "-"+strings.TrimPrefix(t, " "), 1)
+		}
+	}
 	if d.CodeToTestRatio != nil {
 		if d.CodeToTestRatio.Diff > 0 {
 			t2 = strings.Replace(t2, "  | Code to", "+ | Code to", 1)
@@ -124,11 +153,201 @@ func (d *DiffReport) Table() string {
 		}
 	}
 	out = append(out, fmt.Sprintf("<details>\n\n<summary>Details</summary>\n\n``` diff\n%s```\n\n</details>\n", t2))
+	if d.ReportA != nil {
+		if note := carriedForwardNote(d.ReportA.carriedForward); note != "" {
+			out = append(out, note)
+		}
+	}
 
 	return strings.Join(out, "\n")
 }
 
 func (d *DiffReport) FileCoveragesTable(files []*gh.PullRequestFile, relWd string) string {
+	return d.fileCoveragesTable(files, relWd, true)
+}
+
+// PullRequestFileCoveragesTable returns the table of the code coverage of the files in the pull request only.
+// The other files whose code coverage changed are left to IndirectChangesTable.
+func (d *DiffReport) PullRequestFileCoveragesTable(files []*gh.PullRequestFile, relWd string) string {
+	return d.fileCoveragesTable(files, relWd, false)
+}
+
+// IndirectChanges returns the files outside the pull request whose covered or total lines changed.
+// The files losing more covered lines come first.
+func (d *DiffReport) IndirectChanges(files []*gh.PullRequestFile) coverage.DiffFileCoverages {
+	if d.Coverage == nil {
+		return nil
+	}
+	prFiles := d.pullRequestFiles(files)
+	var changes coverage.DiffFileCoverages
+	for _, fc := range d.Coverage.Files {
+		if _, ok := prFiles[fc.File]; ok {
+			continue
+		}
+		covered, total := countsDiff(fc)
+		if covered == 0 && total == 0 {
+			continue
+		}
+		changes = append(changes, fc)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		ci, ti := countsDiff(changes[i])
+		cj, tj := countsDiff(changes[j])
+		if ci != cj {
+			return ci < cj
+		}
+		if ti != tj {
+			return ti > tj
+		}
+		return changes[i].File < changes[j].File
+	})
+	return changes
+}
+
+// IndirectChangesTable returns the table of the indirect coverage changes, listing up to limit files.
+func (d *DiffReport) IndirectChangesTable(files []*gh.PullRequestFile, relWd string, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	changes := d.IndirectChanges(files)
+	if len(changes) == 0 {
+		return ""
+	}
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "### Indirect coverage changes (%d files)\n\n", len(changes))
+	shown := changes
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+	if len(shown) > filesHideMin {
+		buf.WriteString("<details>\n\n")
+	}
+
+	table := tablewriter.NewWriter(buf)
+	h := []string{"Files", "Coverage", "+/-", "Covered +/-", "Lines +/-"}
+	table.SetHeader(h)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	for _, fc := range shown {
+		cover := "-"
+		if fc.FileCoverageA != nil {
+			cover = fmt.Sprintf("%.1f%%", floor1(fc.A))
+		}
+		diff := fmt.Sprintf("%.1f%%", floor1(fc.Diff))
+		if fc.Diff > 0 {
+			diff = fmt.Sprintf("+%s", diff)
+		}
+		covered, total := countsDiff(fc)
+		table.Append([]string{carriedForwardName(d.fileLink(fc, relWd), fc.FileCoverageA), cover, diff, signedInt(covered), signedInt(total)})
+	}
+	table.Render()
+
+	if len(changes) > len(shown) {
+		fmt.Fprintf(buf, "\n... and %d more files\n", len(changes)-len(shown))
+	}
+	if len(shown) > filesHideMin {
+		buf.WriteString("\n</details>\n")
+	}
+
+	return strings.Replace(strings.Replace(buf.String(), "---|", "--:|", len(h)), "--:|", "---|", 1)
+}
+
+// MapLines maps the lines of the previous report to the lines of the files changed in the pull request through their diffs,
+// so that the newly uncovered and covered lines of the files are not shifted by the lines added or removed.
+// The files whose diff is not available have no newly uncovered and covered lines.
+func (d *DiffReport) MapLines(files []*gh.PullRequestFile) {
+	if d.Coverage == nil {
+		return
+	}
+	prFiles := d.pullRequestFiles(files)
+	for _, fc := range d.Coverage.Files {
+		if prf, ok := prFiles[fc.File]; ok {
+			fc.MapLineDeltas(prf.MapOldLine)
+		}
+	}
+}
+
+// NewlyUncoveredLines returns the files having lines covered in the previous report but not covered now.
+// The files losing more lines come first.
+func (d *DiffReport) NewlyUncoveredLines() coverage.DiffFileCoverages {
+	if d.Coverage == nil {
+		return nil
+	}
+	var regressions coverage.DiffFileCoverages
+	for _, fc := range d.Coverage.Files {
+		if len(fc.NewlyUncovered) == 0 {
+			continue
+		}
+		regressions = append(regressions, fc)
+	}
+	sort.SliceStable(regressions, func(i, j int) bool {
+		if len(regressions[i].NewlyUncovered) != len(regressions[j].NewlyUncovered) {
+			return len(regressions[i].NewlyUncovered) > len(regressions[j].NewlyUncovered)
+		}
+		return regressions[i].File < regressions[j].File
+	})
+	return regressions
+}
+
+// NewlyUncoveredLinesTable returns the table of the newly uncovered lines, listing up to limit files.
+func (d *DiffReport) NewlyUncoveredLinesTable(relWd string, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	regressions := d.NewlyUncoveredLines()
+	if len(regressions) == 0 {
+		return ""
+	}
+	lines := 0
+	for _, fc := range regressions {
+		lines += len(fc.NewlyUncovered)
+	}
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "### Newly uncovered lines (%d lines in %d files)\n\n", lines, len(regressions))
+	shown := regressions
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+	if len(shown) > filesHideMin {
+		buf.WriteString("<details>\n\n")
+	}
+
+	table := tablewriter.NewWriter(buf)
+	h := []string{"Files", "Newly uncovered", "Lines"}
+	table.SetHeader(h)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	for _, fc := range shown {
+		name, u := d.fileURL(fc, relWd)
+		var ranges []string
+		for _, lr := range lineRanges(fc.NewlyUncovered) {
+			ranges = append(ranges, lineRangeLink(lr, u))
+		}
+		if u != "" {
+			name = fmt.Sprintf("[%s](%s)", name, u)
+		}
+		table.Append([]string{carriedForwardName(name, fc.FileCoverageA), fmt.Sprintf("%d", len(fc.NewlyUncovered)), strings.Join(ranges, ", ")})
+	}
+	table.Render()
+
+	if len(regressions) > len(shown) {
+		fmt.Fprintf(buf, "\n... and %d more files\n", len(regressions)-len(shown))
+	}
+	if len(shown) > filesHideMin {
+		buf.WriteString("\n</details>\n")
+	}
+
+	// Only the number of the newly uncovered lines is aligned to the right.
+	return strings.Replace(strings.Replace(buf.String(), "---|", "--:|", 2), "--:|", "---|", 1)
+}
+
+// fileCoveragesTable returns the table of the code coverage of the files in the pull request.
+// If affected is true, the other files whose code coverage changed are listed as "affected" too.
+func (d *DiffReport) fileCoveragesTable(files []*gh.PullRequestFile, relWd string, affected bool) string {
 	if d.Coverage == nil {
 		return ""
 	}
@@ -137,7 +356,8 @@ func (d *DiffReport) FileCoveragesTable(files []*gh.PullRequestFile, relWd strin
 	}
 	var t, c, pt, pc int
 	var rows [][]string
-	createRow := func(name string, fc *coverage.DiffFileCoverage, status string) []string {
+	patch := d.ReportA != nil && d.ReportA.hasPatch(files)
+	createRow := func(name string, fc *coverage.DiffFileCoverage, prf *gh.PullRequestFile, status string) []string {
 		diff := fmt.Sprintf("%.1f%%", floor1(fc.Diff))
 		if fc.Diff > 0 {
 			diff = fmt.Sprintf("+%s", diff)
@@ -150,47 +370,27 @@ func (d *DiffReport) FileCoveragesTable(files []*gh.PullRequestFile, relWd strin
 			pc += fc.FileCoverageB.Covered
 			pt += fc.FileCoverageB.Total
 		}
-		return []string{name, fmt.Sprintf("%.1f%%", floor1(fc.A)), diff, status}
-	}
-
-	prFiles := map[string]*gh.PullRequestFile{}
-	for _, f := range files {
-		fc, err := d.Coverage.Files.FuzzyFindByFile(f.Filename)
-		if err != nil {
-			continue
+		if !patch {
+			return []string{name, fmt.Sprintf("%.1f%%", floor1(fc.A)), diff, status}
 		}
-		prFiles[fc.File] = f
+		patchCell := "-"
+		if prf != nil {
+			patchCell = d.ReportA.patchCell(prf)
+		}
+		return []string{name, fmt.Sprintf("%.1f%%", floor1(fc.A)), diff, patchCell, status}
 	}
 
+	prFiles := d.pullRequestFiles(files)
 	for _, fc := range d.Coverage.Files {
 		if prf, ok := prFiles[fc.File]; ok {
-			name := fmt.Sprintf("[%s](%s)", prf.Filename, prf.BlobURL)
-			rows = append(rows, createRow(name, fc, prf.Status))
+			name := carriedForwardName(fmt.Sprintf("[%s](%s)", prf.Filename, prf.BlobURL), fc.FileCoverageA)
+			rows = append(rows, createRow(name, fc, prf, prf.Status))
 			continue
 		}
-		if fc.Diff == 0 {
+		if !affected || fc.Diff == 0 {
 			continue
 		}
-
-		repoURL := fmt.Sprintf("%s/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"))
-		// trim prefix for Go coverage (no sufficient checks on the other formats)
-		name := strings.TrimPrefix(fc.File, strings.TrimPrefix(repoURL, "https://")+"/")
-		commit := ""
-		if fc.FileCoverageA != nil && d.CommitA != "" {
-			commit = d.CommitA
-		} else if fc.FileCoverageB != nil && d.CommitB != "" {
-			commit = d.CommitB
-		}
-
-		filePath := name
-		if relWd != "" && !strings.HasPrefix(filePath, relWd+"/") && !filepath.IsAbs(filePath) {
-			filePath = filepath.Clean(filepath.Join(relWd, filePath))
-		}
-
-		if repoURL != "/" && commit != "" && !filepath.IsAbs(filePath) {
-			name = fmt.Sprintf("[%s](%s/blob/%s/%s)", filePath, repoURL, commit, filePath)
-		}
-		rows = append(rows, createRow(name, fc, "affected"))
+		rows = append(rows, createRow(carriedForwardName(d.fileLink(fc, relWd), fc.FileCoverageA), fc, nil, "affected"))
 	}
 	if len(rows) == 0 {
 		return ""
@@ -219,6 +419,9 @@ func (d *DiffReport) FileCoveragesTable(files []*gh.PullRequestFile, relWd strin
 
 	table := tablewriter.NewWriter(buf)
 	h := []string{"Files", "Coverage", "+/-", "Status"}
+	if patch {
+		h = []string{"Files", "Coverage", "+/-", "Patch", "Status"}
+	}
 	table.SetHeader(h)
 	table.SetAutoFormatHeaders(false)
 	table.SetAutoWrapText(false)
@@ -238,6 +441,51 @@ func (d *DiffReport) FileCoveragesTable(files []*gh.PullRequestFile, relWd strin
 	return strings.Replace(strings.Replace(buf.String(), "---|", "--:|", len(h)), "--:|", "---|", 1)
 }
 
+// pullRequestFiles maps the files in the coverage to the files in the pull request.
+func (d *DiffReport) pullRequestFiles(files []*gh.PullRequestFile) map[string]*gh.PullRequestFile {
+	prFiles := map[string]*gh.PullRequestFile{}
+	for _, f := range files {
+		fc, err := d.Coverage.Files.FuzzyFindByFile(f.Filename)
+		if err != nil {
+			continue
+		}
+		prFiles[fc.File] = f
+	}
+	return prFiles
+}
+
+// fileLink returns the name of the file outside the pull request, linked to the file in the repository if possible.
+func (d *DiffReport) fileLink(fc *coverage.DiffFileCoverage, relWd string) string {
+	name, u := d.fileURL(fc, relWd)
+	if u != "" {
+		name = fmt.Sprintf("[%s](%s)", name, u)
+	}
+	return name
+}
+
+// fileURL returns the path of the file in the repository and its URL, or the name of the file and an empty URL if the file cannot be linked.
+func (d *DiffReport) fileURL(fc *coverage.DiffFileCoverage, relWd string) (string, string) {
+	repoURL := fmt.Sprintf("%s/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"))
+	// trim prefix for Go coverage (no sufficient checks on the other formats)
+	name := strings.TrimPrefix(fc.File, strings.TrimPrefix(repoURL, "https://")+"/")
+	commit := ""
+	if fc.FileCoverageA != nil && d.CommitA != "" {
+		commit = d.CommitA
+	} else if fc.FileCoverageB != nil && d.CommitB != "" {
+		commit = d.CommitB
+	}
+
+	filePath := name
+	if relWd != "" && !strings.HasPrefix(filePath, relWd+"/") && !filepath.IsAbs(filePath) {
+		filePath = filepath.Clean(filepath.Join(relWd, filePath))
+	}
+
+	if repoURL != "/" && commit != "" && !filepath.IsAbs(filePath) {
+		return filePath, fmt.Sprintf("%s/blob/%s/%s", repoURL, commit, filePath)
+	}
+	return name, ""
+}
+
 func (d *DiffReport) renderTable(table *tablewriter.Table, g, r, b tablewriter.Colors, detail bool, withLink bool) {
 	if withLink {
 		table.SetHeader([]string{"", makeHeadTitleWithLink(d.RefB, d.CommitB, d.ReportB.covPaths), makeHeadTitleWithLink(d.RefA, d.CommitA, d.ReportA.covPaths), "+/-"})
@@ -290,7 +538,105 @@ func (d *DiffReport) renderTable(table *tablewriter.Table, g, r, b tablewriter.C
 				table.Append([]string{"  Covered", fmt.Sprintf("%d", d.Coverage.CoverageB.Covered), fmt.Sprintf("%d", d.Coverage.CoverageA.Covered), ds})
 			}
 		}
-
+		for _, s := range d.Suites {
+			dd := s.Diff
+			ds := fmt.Sprintf("%.1f%%", floor1(dd))
+			cc := tablewriter.Colors{}
+			if dd > 0 {
+				ds = fmt.Sprintf("+%.1f%%", floor1(dd))
+				cc = g
+			} else if dd < 0 {
+				ds = fmt.Sprintf("%.1f%%", floor1(dd))
+				cc = r
+			}
+			suiteB := "-"
+			if s.B != nil {
+				suiteB = fmt.Sprintf("%.1f%%", floor1(*s.B))
+			}
+			t := suiteTitle(s.Label)
+			if !detail {
+				t = fmt.Sprintf("**%s**", t)
+			}
+			table.Rich([]string{t, suiteB, fmt.Sprintf("%.1f%%", floor1(s.A)), ds}, []tablewriter.Colors{b, tablewriter.Colors{}, tablewriter.Colors{}, cc})
+		}
+		if d.Coverage.IsMeasuredBranch() {
+			dd := d.Coverage.BranchDiff
+			ds := fmt.Sprintf("%.1f%%", floor1(dd))
+			cc := tablewriter.Colors{}
+			if dd > 0 {
+				ds = fmt.Sprintf("+%.1f%%", floor1(dd))
+				cc = g
+			} else if dd < 0 {
+				ds = fmt.Sprintf("%.1f%%", floor1(dd))
+				cc = r
+			}
+			branchA := "-"
+			branchB := "-"
+			if d.Coverage.CoverageA != nil && d.Coverage.CoverageA.BranchTotal > 0 {
+				branchA = fmt.Sprintf("%.1f%%", floor1(d.Coverage.BranchA))
+			}
+			if d.Coverage.CoverageB != nil && d.Coverage.CoverageB.BranchTotal > 0 {
+				branchB = fmt.Sprintf("%.1f%%", floor1(d.Coverage.BranchB))
+			}
+			t := "Branch Coverage"
+			if !detail {
+				t = "**Branch Coverage**"
+			}
+			table.Rich([]string{t, branchB, branchA, ds}, []tablewriter.Colors{b, tablewriter.Colors{}, tablewriter.Colors{}, cc})
+		}
+		if d.Coverage.IsMeasuredFunction() {
+			dd := d.Coverage.FunctionDiff
+			ds := fmt.Sprintf("%.1f%%", floor1(dd))
+			cc := tablewriter.Colors{}
+			if dd > 0 {
+				ds = fmt.Sprintf("+%.1f%%", floor1(dd))
+				cc = g
+			} else if dd < 0 {
+				ds = fmt.Sprintf("%.1f%%", floor1(dd))
+				cc = r
+			}
+			funcA := "-"
+			funcB := "-"
+			if d.Coverage.CoverageA != nil && d.Coverage.CoverageA.FunctionTotal > 0 {
+				funcA = fmt.Sprintf("%.1f%%", floor1(d.Coverage.FunctionA))
+			}
+			if d.Coverage.CoverageB != nil && d.Coverage.CoverageB.FunctionTotal > 0 {
+				funcB = fmt.Sprintf("%.1f%%", floor1(d.Coverage.FunctionB))
+			}
+			t := "Function Coverage"
+			if !detail {
+				t = "**Function Coverage**"
+			}
+			table.Rich([]string{t, funcB, funcA, ds}, []tablewriter.Colors{b, tablewriter.Colors{}, tablewriter.Colors{}, cc})
+		}
+	}
+	if d.Patch != nil {
+		t := "Patch Coverage"
+		if !detail {
+			t = "**Patch Coverage**"
+		}
+		table.Rich([]string{t, "-", fmt.Sprintf("%.1f%%", floor1(d.Patch.Percent())), ""}, []tablewriter.Colors{b, tablewriter.Colors{}, tablewriter.Colors{}, tablewriter.Colors{}})
+	}
+	for _, c := range d.Components {
+		dd := c.Diff
+		ds := fmt.Sprintf("%.1f%%", floor1(dd))
+		cc := tablewriter.Colors{}
+		if dd > 0 {
+			ds = fmt.Sprintf("+%.1f%%", floor1(dd))
+			cc = g
+		} else if dd < 0 {
+			ds = fmt.Sprintf("%.1f%%", floor1(dd))
+			cc = r
+		}
+		compB := "-"
+		if c.B != nil {
+			compB = fmt.Sprintf("%.1f%%", floor1(*c.B))
+		}
+		t := componentTitle(c.Name)
+		if !detail {
+			t = fmt.Sprintf("**%s**", t)
+		}
+		table.Rich([]string{t, compB, fmt.Sprintf("%.1f%%", floor1(c.A)), ds}, []tablewriter.Colors{b, tablewriter.Colors{}, tablewriter.Colors{}, cc})
 	}
 	if d.CodeToTestRatio != nil {
 		dd := d.CodeToTestRatio.Diff
@@ -362,3 +708,49 @@ func (d *DiffReport) renderTable(table *tablewriter.Table, g, r, b tablewriter.C
 		table.Rich([]string{t, tb, ta, ds}, []tablewriter.Colors{b, tablewriter.Colors{}, tablewriter.Colors{}, cc})
 	}
 }
+
+// countsDiff returns the differences of the covered and total lines of the file.
+func countsDiff(fc *coverage.DiffFileCoverage) (int, int) {
+	var covered, total int
+	if fc.FileCoverageA != nil {
+		covered += fc.FileCoverageA.Covered
+		total += fc.FileCoverageA.Total
+	}
+	if fc.FileCoverageB != nil {
+		covered -= fc.FileCoverageB.Covered
+		total -= fc.FileCoverageB.Total
+	}
+	return covered, total
+}
+
+// lineRanges groups the sorted lines into the ranges of consecutive lines.
+func lineRanges(lines []int) [][2]int {
+	var ranges [][2]int
+	for _, l := range lines {
+		if len(ranges) > 0 && ranges[len(ranges)-1][1]+1 == l {
+			ranges[len(ranges)-1][1] = l
+			continue
+		}
+		ranges = append(ranges, [2]int{l, l})
+	}
+	return ranges
+}
+
+// lineRangeLink returns the range of lines such as L3-L5, linked to the lines of the file URL if it is not empty.
+func lineRangeLink(lr [2]int, fileURL string) string {
+	r := fmt.Sprintf("L%d", lr[0])
+	if lr[1] != lr[0] {
+		r = fmt.Sprintf("L%d-L%d", lr[0], lr[1])
+	}
+	if fileURL == "" {
+		return r
+	}
+	return fmt.Sprintf("[%s](%s#%s)", r, fileURL, r)
+}
+
+func signedInt(v int) string {
+	if v > 0 {
+		return fmt.Sprintf("+%d", v)
+	}
+	return fmt.Sprintf("%d", v)
+}
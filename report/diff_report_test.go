@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/k1LoW/octocov/coverage"
 	"github.com/k1LoW/octocov/gh"
 	"github.com/tenntenn/golden"
 )
@@ -56,6 +59,202 @@ func TestDiffTable(t *testing.T) {
 	}
 }
 
+func TestDiffTableWithBranchCoverage(t *testing.T) {
+	a := &Report{}
+	if err := a.Load(filepath.Join(testdataDir(t), "reports", "k1LoW", "tbls", "report2.json")); err != nil {
+		t.Fatal(err)
+	}
+	b := &Report{}
+	if err := b.Load(filepath.Join(testdataDir(t), "reports", "k1LoW", "awspec", "report.json")); err != nil {
+		t.Fatal(err)
+	}
+	a.Coverage.BranchTotal = 4
+	a.Coverage.BranchCovered = 3
+	b.Coverage.BranchTotal = 4
+	b.Coverage.BranchCovered = 2
+
+	d := a.Compare(b)
+	if want := 25.0; d.Coverage.BranchDiff != want {
+		t.Errorf("got %v\nwant %v", d.Coverage.BranchDiff, want)
+	}
+	got := d.Table()
+	for _, want := range []string{
+		"| **Branch Coverage**",
+		"50.0% |",
+		"75.0% |",
+		"+25.0% |",
+		"+ | Branch Coverage",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got\n%v\nwant to contain %q", got, want)
+		}
+	}
+}
+
+func TestDiffTableWithComponents(t *testing.T) {
+	a := &Report{}
+	if err := a.Load(filepath.Join(testdataDir(t), "reports", "k1LoW", "tbls", "report2.json")); err != nil {
+		t.Fatal(err)
+	}
+	b := &Report{}
+	if err := b.Load(filepath.Join(testdataDir(t), "reports", "k1LoW", "awspec", "report.json")); err != nil {
+		t.Fatal(err)
+	}
+	a.Components = []*ComponentCoverage{
+		{Name: "api", Total: 4, Covered: 3},
+		{Name: "web", Total: 4, Covered: 1},
+	}
+	b.Components = []*ComponentCoverage{
+		{Name: "web", Total: 4, Covered: 2},
+	}
+
+	got := a.Compare(b).Table()
+	for _, want := range []string{
+		"| **Coverage (api)**",
+		"+75.0% |",
+		"| **Coverage (web)**",
+		"-25.0% |",
+		"+ | Coverage (api)",
+		"- | Coverage (web)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got\n%v\nwant to contain %q", got, want)
+		}
+	}
+}
+
+func TestDiffTableWithPatchCoverage(t *testing.T) {
+	a := &Report{}
+	if err := a.Load(filepath.Join(testdataDir(t), "reports", "k1LoW", "tbls", "report2.json")); err != nil {
+		t.Fatal(err)
+	}
+	b := &Report{}
+	if err := b.Load(filepath.Join(testdataDir(t), "reports", "k1LoW", "awspec", "report.json")); err != nil {
+		t.Fatal(err)
+	}
+	a.Patch = &PatchCoverage{Total: 4, Covered: 3}
+
+	got := a.Compare(b).Table()
+	for _, want := range []string{
+		"| **Patch Coverage**",
+		"75.0% |",
+		"  | Patch Coverage",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got\n%v\nwant to contain %q", got, want)
+		}
+	}
+}
+
+func TestDiffIndirectChanges(t *testing.T) {
+	newFile := func(file string, total, covered int) *coverage.FileCoverage {
+		return &coverage.FileCoverage{File: file, Total: total, Covered: covered}
+	}
+	a := &Report{
+		Coverage: &coverage.Coverage{
+			Files: coverage.FileCoverages{
+				newFile("app/changed.go", 10, 10),
+				newFile("app/lost.go", 10, 4),
+				newFile("app/new.go", 5, 5),
+				newFile("app/shrunk.go", 8, 8),
+				newFile("app/unchanged.go", 10, 5),
+			},
+		},
+	}
+	b := &Report{
+		Coverage: &coverage.Coverage{
+			Files: coverage.FileCoverages{
+				newFile("app/changed.go", 10, 2),
+				newFile("app/lost.go", 10, 8),
+				newFile("app/removed.go", 4, 1),
+				newFile("app/shrunk.go", 10, 10),
+				newFile("app/unchanged.go", 10, 5),
+			},
+		},
+	}
+	files := []*gh.PullRequestFile{
+		{Filename: "app/changed.go", Status: "modified"},
+	}
+	d := a.Compare(b)
+
+	var got []string
+	for _, fc := range d.IndirectChanges(files) {
+		got = append(got, fc.File)
+	}
+	want := []string{"app/lost.go", "app/shrunk.go", "app/removed.go", "app/new.go"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+
+	table := d.IndirectChangesTable(files, "", 3)
+	for _, w := range []string{
+		"### Indirect coverage changes (4 files)",
+		"| app/lost.go ",
+		"| app/removed.go ",
+		"... and 1 more files",
+	} {
+		if !strings.Contains(table, w) {
+			t.Errorf("got\n%v\nwant to contain %q", table, w)
+		}
+	}
+	if strings.Contains(table, "app/new.go") || strings.Contains(table, "app/changed.go") {
+		t.Errorf("got\n%v\nwant to list only the first 3 files outside the pull request", table)
+	}
+	if got := d.IndirectChangesTable(files, "", 0); got != "" {
+		t.Errorf("got\n%v\nwant empty", got)
+	}
+
+	if got := d.FileCoveragesTable(files, ""); !strings.Contains(got, "| app/lost.go ") {
+		t.Errorf("got\n%v\nwant to list app/lost.go as affected", got)
+	}
+	got2 := d.PullRequestFileCoveragesTable(files, "")
+	if !strings.Contains(got2, "app/changed.go") || strings.Contains(got2, "affected") {
+		t.Errorf("got\n%v\nwant to list only the files in the pull request", got2)
+	}
+}
+
+func TestDiffNewlyUncoveredLines(t *testing.T) {
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "k1LoW/octocov")
+	newFile := func(file string, lines ...int) *coverage.DiffFileCoverage {
+		return &coverage.DiffFileCoverage{File: file, NewlyUncovered: lines, FileCoverageA: &coverage.FileCoverage{File: file}}
+	}
+	d := &DiffReport{
+		CommitA: "abcdef",
+		Coverage: &coverage.DiffCoverage{
+			Files: coverage.DiffFileCoverages{
+				newFile("app/few.go", 7),
+				newFile("app/none.go"),
+				newFile("app/many.go", 3, 4, 5, 9),
+			},
+		},
+	}
+
+	var got []string
+	for _, fc := range d.NewlyUncoveredLines() {
+		got = append(got, fc.File)
+	}
+	if diff := cmp.Diff([]string{"app/many.go", "app/few.go"}, got); diff != "" {
+		t.Error(diff)
+	}
+
+	table := d.NewlyUncoveredLinesTable("", 1)
+	for _, w := range []string{
+		"### Newly uncovered lines (5 lines in 2 files)",
+		"| [app/many.go](https://github.com/k1LoW/octocov/blob/abcdef/app/many.go) |",
+		"| [L3-L5](https://github.com/k1LoW/octocov/blob/abcdef/app/many.go#L3-L5), [L9](https://github.com/k1LoW/octocov/blob/abcdef/app/many.go#L9) |",
+		"|----------------:|",
+		"... and 1 more files",
+	} {
+		if !strings.Contains(table, w) {
+			t.Errorf("got\n%v\nwant to contain %q", table, w)
+		}
+	}
+	if got := d.NewlyUncoveredLinesTable("", 0); got != "" {
+		t.Errorf("got\n%v\nwant empty", got)
+	}
+}
+
 func TestDiffFileCoveragesTable(t *testing.T) {
 	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
 	t.Setenv("GITHUB_REPOSITORY", "k1LoW/octocov")
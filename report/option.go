@@ -1,9 +1,27 @@
 package report
 
-import "golang.org/x/text/language"
+import (
+	"runtime"
+
+	"golang.org/x/text/language"
+)
 
 type Options struct {
 	Locale *language.Tag
+	// Formats maps coverage report paths to the formats to parse them with.
+	Formats map[string]string
+	// Labels maps coverage report paths to the labels of the test suites they are attributed to.
+	Labels map[string]string
+	// AggregateLines keeps only the per-line counts of coverage reports to save memory.
+	AggregateLines bool
+	// Concurrency is the number of coverage reports parsed at the same time. GOMAXPROCS is used if it is not positive.
+	Concurrency int
+	// Components maps component names to the patterns of the files they contain.
+	Components map[string][]string
+	// ExcludeGenerated excludes the files detected as generated sources.
+	ExcludeGenerated bool
+	// Include is the patterns of the source files counted as uncovered when they are missing from the coverage reports.
+	Include []string
 }
 
 type Option func(*Options)
@@ -13,3 +31,101 @@ func Locale(locale *language.Tag) Option {
 		args.Locale = locale
 	}
 }
+
+// Formats sets the formats of coverage report paths. Paths without a format are detected.
+func Formats(formats map[string]string) Option {
+	return func(args *Options) {
+		args.Formats = formats
+	}
+}
+
+// Labels sets the labels of the test suites of coverage report paths. Paths without a label are not attributed to any test suite.
+func Labels(labels map[string]string) Option {
+	return func(args *Options) {
+		args.Labels = labels
+	}
+}
+
+// AggregateLines keeps only the per-line counts of coverage reports instead of their blocks to save memory.
+func AggregateLines(enable bool) Option {
+	return func(args *Options) {
+		args.AggregateLines = enable
+	}
+}
+
+// Concurrency sets the number of coverage reports parsed at the same time.
+func Concurrency(n int) Option {
+	return func(args *Options) {
+		args.Concurrency = n
+	}
+}
+
+// Components sets the components measured along with the code coverage.
+func Components(components map[string][]string) Option {
+	return func(args *Options) {
+		args.Components = components
+	}
+}
+
+// ExcludeGenerated excludes the files detected as generated sources from the code coverage.
+func ExcludeGenerated(enable bool) Option {
+	return func(args *Options) {
+		args.ExcludeGenerated = enable
+	}
+}
+
+// Include counts the source files matching the patterns but missing from the coverage reports as uncovered.
+func Include(patterns []string) Option {
+	return func(args *Options) {
+		args.Include = patterns
+	}
+}
+
+func (o *Options) formatOf(path string) string {
+	if o == nil {
+		return ""
+	}
+	return o.Formats[path]
+}
+
+func (o *Options) labelOf(path string) string {
+	if o == nil {
+		return ""
+	}
+	return o.Labels[path]
+}
+
+func (o *Options) aggregateLines() bool {
+	if o == nil {
+		return false
+	}
+	return o.AggregateLines
+}
+
+func (o *Options) concurrency() int {
+	if o == nil || o.Concurrency <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return o.Concurrency
+}
+
+func (o *Options) components() map[string][]string {
+	if o == nil {
+		return nil
+	}
+	return o.Components
+}
+
+func (o *Options) excludeGenerated() bool {
+	if o == nil {
+		return false
+	}
+	return o.ExcludeGenerated
+}
+
+func (o *Options) include() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Include
+}
@@ -0,0 +1,77 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/k1LoW/octocov/gh"
+)
+
+// PatchCoverage is the code coverage of the lines added or changed in the pull request.
+type PatchCoverage struct {
+	Total   int `json:"total"`
+	Covered int `json:"covered"`
+}
+
+func (p *PatchCoverage) Percent() float64 {
+	if p == nil || p.Total == 0 {
+		return 0.0
+	}
+	return float64(p.Covered) / float64(p.Total) * 100
+}
+
+// MeasurePatchCoverage measures the code coverage of the coverable lines added or changed in the files.
+func (r *Report) MeasurePatchCoverage(files []*gh.PullRequestFile) {
+	if r.Coverage == nil {
+		return
+	}
+	p := &PatchCoverage{}
+	for _, f := range files {
+		t, c := r.filePatchCoverage(f)
+		p.Total += t
+		p.Covered += c
+	}
+	r.Patch = p
+}
+
+// IsMeasuredPatchCoverage reports whether the pull request changes coverable lines.
+func (r *Report) IsMeasuredPatchCoverage() bool {
+	return r != nil && r.Patch != nil && r.Patch.Total > 0
+}
+
+func (r *Report) PatchCoveragePercent() float64 {
+	if r == nil {
+		return 0.0
+	}
+	return r.Patch.Percent()
+}
+
+// filePatchCoverage returns the number of the coverable lines changed in the file, and the number of them covered.
+func (r *Report) filePatchCoverage(f *gh.PullRequestFile) (int, int) {
+	if r.Coverage == nil || len(f.ChangedLines) == 0 {
+		return 0, 0
+	}
+	fc, err := r.Coverage.Files.FuzzyFindByFile(f.Filename)
+	if err != nil {
+		return 0, 0
+	}
+	return fc.CountLines(f.ChangedLines)
+}
+
+// patchCell returns the patch coverage of the file for tables.
+func (r *Report) patchCell(f *gh.PullRequestFile) string {
+	t, c := r.filePatchCoverage(f)
+	if t == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", floor1(float64(c)/float64(t)*100))
+}
+
+// hasPatch reports whether any of the files changes coverable lines.
+func (r *Report) hasPatch(files []*gh.PullRequestFile) bool {
+	for _, f := range files {
+		if t, _ := r.filePatchCoverage(f); t > 0 {
+			return true
+		}
+	}
+	return false
+}
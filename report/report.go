@@ -37,18 +37,25 @@ var (
 )
 
 type Report struct {
-	Repository        string             `json:"repository"`
-	Ref               string             `json:"ref"`
-	Commit            string             `json:"commit"`
-	Coverage          *coverage.Coverage `json:"coverage,omitempty"`
-	CodeToTestRatio   *ratio.Ratio       `json:"code_to_test_ratio,omitempty"`
-	TestExecutionTime *float64           `json:"test_execution_time,omitempty"`
-	Timestamp         time.Time          `json:"timestamp"`
-	CustomMetrics     []*CustomMetricSet `json:"custom_metrics,omitempty"`
+	Repository        string               `json:"repository"`
+	Ref               string               `json:"ref"`
+	Commit            string               `json:"commit"`
+	Coverage          *coverage.Coverage   `json:"coverage,omitempty"`
+	CodeToTestRatio   *ratio.Ratio         `json:"code_to_test_ratio,omitempty"`
+	TestExecutionTime *float64             `json:"test_execution_time,omitempty"`
+	Timestamp         time.Time            `json:"timestamp"`
+	CustomMetrics     []*CustomMetricSet   `json:"custom_metrics,omitempty"`
+	Suites            []*SuiteCoverage     `json:"suites,omitempty"`
+	Components        []*ComponentCoverage `json:"components,omitempty"`
+	Patch             *PatchCoverage       `json:"patch,omitempty"`
 
 	// coverage report paths
 	covPaths []string
-	opts     *Options
+	// files excluded as generated sources
+	generatedFiles []*coverage.GeneratedFile
+	// groups of files carried forward from the previous report
+	carriedForward []string
+	opts           *Options
 }
 
 func New(ownerrepo string, opts ...Option) (*Report, error) {
@@ -125,6 +132,26 @@ func (r *Report) Table() string {
 		h = append(h, "Coverage")
 		m = append(m, fmt.Sprintf("%.1f%%", floor1(r.CoveragePercent())))
 	}
+	for _, s := range r.Suites {
+		h = append(h, s.Title())
+		m = append(m, fmt.Sprintf("%.1f%%", floor1(s.Percent())))
+	}
+	if r.IsMeasuredBranchCoverage() {
+		h = append(h, "Branch Coverage")
+		m = append(m, fmt.Sprintf("%.1f%%", floor1(r.BranchCoveragePercent())))
+	}
+	if r.IsMeasuredFunctionCoverage() {
+		h = append(h, "Function Coverage")
+		m = append(m, fmt.Sprintf("%.1f%%", floor1(r.FunctionCoveragePercent())))
+	}
+	if r.IsMeasuredPatchCoverage() {
+		h = append(h, "Patch Coverage")
+		m = append(m, fmt.Sprintf("%.1f%%", floor1(r.PatchCoveragePercent())))
+	}
+	for _, c := range r.Components {
+		h = append(h, c.Title())
+		m = append(m, fmt.Sprintf("%.1f%%", floor1(c.Percent())))
+	}
 	if r.IsMeasuredCodeToTestRatio() {
 		h = append(h, "Code to Test Ratio")
 		m = append(m, fmt.Sprintf("1:%.1f", floor1(r.CodeToTestRatioRatio())))
@@ -143,7 +170,7 @@ func (r *Report) Table() string {
 	table.SetCenterSeparator("|")
 	table.Append(m)
 	table.Render()
-	return strings.Replace(buf.String(), "---|", "--:|", len(h))
+	return strings.Replace(buf.String(), "---|", "--:|", len(h)) + carriedForwardNote(r.carriedForward)
 }
 
 func (r *Report) Out(w io.Writer) error {
@@ -162,6 +189,26 @@ func (r *Report) Out(w io.Writer) error {
 		table.Rich([]string{"Coverage", fmt.Sprintf("%.1f%%", floor1(r.CoveragePercent()))}, []tablewriter.Colors{tablewriter.Colors{tablewriter.Bold}, tablewriter.Colors{}})
 	}
 
+	for _, s := range r.Suites {
+		table.Rich([]string{s.Title(), fmt.Sprintf("%.1f%%", floor1(s.Percent()))}, []tablewriter.Colors{tablewriter.Colors{tablewriter.Bold}, tablewriter.Colors{}})
+	}
+
+	if r.IsMeasuredBranchCoverage() {
+		table.Rich([]string{"Branch Coverage", fmt.Sprintf("%.1f%%", floor1(r.BranchCoveragePercent()))}, []tablewriter.Colors{tablewriter.Colors{tablewriter.Bold}, tablewriter.Colors{}})
+	}
+
+	if r.IsMeasuredFunctionCoverage() {
+		table.Rich([]string{"Function Coverage", fmt.Sprintf("%.1f%%", floor1(r.FunctionCoveragePercent()))}, []tablewriter.Colors{tablewriter.Colors{tablewriter.Bold}, tablewriter.Colors{}})
+	}
+
+	if r.IsMeasuredPatchCoverage() {
+		table.Rich([]string{"Patch Coverage", fmt.Sprintf("%.1f%%", floor1(r.PatchCoveragePercent()))}, []tablewriter.Colors{tablewriter.Colors{tablewriter.Bold}, tablewriter.Colors{}})
+	}
+
+	for _, c := range r.Components {
+		table.Rich([]string{c.Title(), fmt.Sprintf("%.1f%%", floor1(c.Percent()))}, []tablewriter.Colors{tablewriter.Colors{tablewriter.Bold}, tablewriter.Colors{}})
+	}
+
 	if r.IsMeasuredCodeToTestRatio() {
 		table.Rich([]string{"Code to Test Ratio", fmt.Sprintf("1:%.1f", floor1(r.CodeToTestRatioRatio()))}, []tablewriter.Colors{tablewriter.Colors{tablewriter.Bold}, tablewriter.Colors{}})
 	}
@@ -195,6 +242,7 @@ func (r *Report) FileCoveragesTable(files []*gh.PullRequestFile) string {
 	}
 	var t, c int
 	exist := false
+	patch := r.hasPatch(files)
 	var rows [][]string
 	for _, f := range files {
 		fc, err := r.Coverage.Files.FuzzyFindByFile(f.Filename)
@@ -208,7 +256,11 @@ func (r *Report) FileCoveragesTable(files []*gh.PullRequestFile) string {
 		if fc.Total == 0 {
 			cover = 0.0
 		}
-		rows = append(rows, []string{fmt.Sprintf("[%s](%s)", f.Filename, f.BlobURL), fmt.Sprintf("%.1f%%", floor1(cover))})
+		row := []string{carriedForwardName(fmt.Sprintf("[%s](%s)", f.Filename, f.BlobURL), fc), fmt.Sprintf("%.1f%%", floor1(cover))}
+		if patch {
+			row = append(row, r.patchCell(f))
+		}
+		rows = append(rows, row)
 	}
 	if !exist {
 		return ""
@@ -233,6 +285,9 @@ func (r *Report) FileCoveragesTable(files []*gh.PullRequestFile) string {
 
 	table := tablewriter.NewWriter(buf)
 	h := []string{"Files", "Coverage"}
+	if patch {
+		h = append(h, "Patch")
+	}
 	table.SetHeader(h)
 	table.SetAutoFormatHeaders(false)
 	table.SetAutoWrapText(false)
@@ -269,6 +324,16 @@ func (r *Report) IsMeasuredCoverage() bool {
 	return r.Coverage != nil
 }
 
+// IsMeasuredBranchCoverage reports whether the coverage report contains branch data.
+func (r *Report) IsMeasuredBranchCoverage() bool {
+	return r.Coverage != nil && r.Coverage.BranchTotal > 0
+}
+
+// IsMeasuredFunctionCoverage reports whether the coverage report contains function data.
+func (r *Report) IsMeasuredFunctionCoverage() bool {
+	return r.Coverage != nil && r.Coverage.FunctionTotal > 0
+}
+
 func (r *Report) IsMeasuredCodeToTestRatio() bool {
 	return r.CodeToTestRatio != nil
 }
@@ -304,35 +369,46 @@ func (r *Report) MeasureCoverage(patterns, exclude []string) error {
 	if len(patterns) == 0 {
 		return fmt.Errorf("coverage report not found: %s", patterns)
 	}
-	var paths []string
+	var (
+		paths   []string
+		formats []string
+		labels  []string
+	)
 	for _, pattern := range patterns {
 		p, err := doublestar.FilepathGlob(pattern)
 		if err != nil {
 			return err
 		}
 		paths = append(paths, p...)
+		for range p {
+			formats = append(formats, r.opts.formatOf(pattern))
+			labels = append(labels, r.opts.labelOf(pattern))
+		}
 	}
 
 	// Collect filesystem files for path normalization
 	gitRoot, fsFiles := collectFSFilesForNormalization()
 
+	b := coverage.NewBuilder(
+		coverage.NormalizeWith(gitRoot, fsFiles),
+		coverage.AggregateLines(r.opts.aggregateLines()),
+	)
+	b.Add(r.Coverage)
 	var errs error
-	for _, path := range paths {
-		cov, rp, err := challengeParseReport(path)
-		if err != nil {
-			errs = errors.Join(errs, err)
+	// Reports are merged in the order of paths regardless of the order they are parsed in.
+	for res := range parseReports(paths, formats, labels, r.opts.concurrency(), r.opts.aggregateLines()) {
+		if res.err != nil {
+			errs = errors.Join(errs, res.err)
 			continue
 		}
-		cov.NormalizePaths(gitRoot, fsFiles)
-		if r.Coverage == nil {
-			r.Coverage = cov
-		} else {
-			if err := r.Coverage.Merge(cov); err != nil {
-				return errors.Join(errs, err)
-			}
-		}
-		r.covPaths = append(r.covPaths, rp)
+		b.Add(res.cov)
+		r.covPaths = append(r.covPaths, res.rp)
 	}
+	cov, err := b.Coverage()
+	if err != nil {
+		return errors.Join(errs, err)
+	}
+	r.Coverage = cov
 
 	// fallback load report.json
 	if r.Coverage == nil && len(paths) == 1 {
@@ -352,13 +428,73 @@ func (r *Report) MeasureCoverage(patterns, exclude []string) error {
 		return nil
 	}
 
+	// Untested files are added first so that functions, ignore pragmas and generated sources are handled for them too.
+	if err := r.Coverage.Include(gitRoot, fsFiles, r.opts.include(), ratio.CodeLines); err != nil {
+		return errors.Join(errs, err)
+	}
+
+	r.Coverage.SetGoFunctions(gitRoot)
+	// The ignored lines are dropped from Total and Covered by reCalc in Exclude.
+	r.Coverage.ApplyIgnorePragmas(gitRoot)
+
+	if r.opts.excludeGenerated() {
+		generated, err := r.Coverage.ExcludeGenerated(gitRoot)
+		if err != nil {
+			return errors.Join(errs, err)
+		}
+		r.generatedFiles = append(r.generatedFiles, generated...)
+	}
+
 	if err := r.Coverage.Exclude(exclude); err != nil {
 		return errors.Join(errs, err)
 	}
 
+	r.measureSuites()
+
+	if err := r.measureComponents(r.opts.components()); err != nil {
+		return errors.Join(errs, err)
+	}
+
 	return nil
 }
 
+// GeneratedFiles returns the files excluded from the code coverage as generated sources.
+func (r *Report) GeneratedFiles() []*coverage.GeneratedFile {
+	return r.generatedFiles
+}
+
+// CarryForward carries the coverage of the groups of files without fresh coverage forward from the previous report.
+// Each group is a doublestar pattern of the files measured together, such as the files of a test suite.
+func (r *Report) CarryForward(prev *Report, groups []string) error {
+	if prev == nil || prev.Coverage == nil || len(groups) == 0 {
+		return nil
+	}
+	cov := r.Coverage
+	if cov == nil {
+		// All of the groups may be skipped.
+		cov = &coverage.Coverage{
+			Type:  prev.Coverage.Type,
+			Files: coverage.FileCoverages{},
+		}
+	}
+	carried, err := cov.CarryForward(prev.Coverage, groups)
+	if err != nil {
+		return err
+	}
+	if len(carried) == 0 {
+		return nil
+	}
+	r.Coverage = cov
+	r.carriedForward = append(r.carriedForward, carried...)
+	r.measureSuites()
+	return r.measureComponents(r.opts.components())
+}
+
+// CarriedForward returns the groups of files whose coverage is carried forward from the previous report.
+func (r *Report) CarriedForward() []string {
+	return r.carriedForward
+}
+
 // collectFSFilesForNormalization detects git root and collects filesystem files.
 // Returns empty values on failure (graceful degradation).
 func collectFSFilesForNormalization() (string, []string) {
@@ -520,6 +656,20 @@ func (r *Report) CoveragePercent() float64 {
 	return float64(r.Coverage.Covered) / float64(r.Coverage.Total) * 100
 }
 
+func (r *Report) BranchCoveragePercent() float64 {
+	if r == nil {
+		return 0.0
+	}
+	return r.Coverage.BranchPercent()
+}
+
+func (r *Report) FunctionCoveragePercent() float64 {
+	if r == nil {
+		return 0.0
+	}
+	return r.Coverage.FunctionPercent()
+}
+
 func (r *Report) CodeToTestRatioRatio() float64 {
 	if r == nil || r.CodeToTestRatio == nil || r.CodeToTestRatio.Code == 0 {
 		return 0.0
@@ -587,6 +737,15 @@ func (r *Report) Compare(r2 *Report) *DiffReport {
 		dt.Diff = t1 - t2
 		d.TestExecutionTime = dt
 	}
+	if r.IsMeasuredPatchCoverage() {
+		d.Patch = r.Patch
+	}
+	for _, s := range r.Suites {
+		d.Suites = append(d.Suites, s.Compare(r2.findSuiteByLabel(s.Label)))
+	}
+	for _, c := range r.Components {
+		d.Components = append(d.Components, c.Compare(r2.findComponentByName(c.Name)))
+	}
 	if r.IsCollectedCustomMetrics() {
 		for _, set := range r.CustomMetrics {
 			set2 := r2.findCustomMetricSetByKey(set.Key)
@@ -687,6 +846,26 @@ func (r *Report) convertFormat(v any) string {
 	}
 }
 
+// carriedForwardName marks the name of the file in tables if it is carried forward from the previous report.
+func carriedForwardName(name string, fc *coverage.FileCoverage) string {
+	if fc == nil || !fc.CarriedForward {
+		return name
+	}
+	return fmt.Sprintf("%s (carried forward)", name)
+}
+
+// carriedForwardNote returns the note on the groups of files carried forward from the previous report.
+func carriedForwardNote(groups []string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	quoted := make([]string, 0, len(groups))
+	for _, g := range groups {
+		quoted = append(quoted, fmt.Sprintf("`%s`", g))
+	}
+	return fmt.Sprintf("\nThe coverage of %s is carried forward from the previous report.\n", strings.Join(quoted, ", "))
+}
+
 func makeHeadTitle(ref, commit string, covPaths []string) string {
 	ref = strings.TrimPrefix(ref, "refs/heads/")
 	if strings.HasPrefix(ref, "refs/pull/") {
@@ -757,55 +936,113 @@ func mergeExecutionTimes(steps []gh.Step) time.Duration {
 	return d
 }
 
-func challengeParseReport(path string) (*coverage.Coverage, string, error) {
-	var errs []error
-	// gocover
-	if cov, rp, err := coverage.NewGocover().ParseReport(path); err == nil {
-		return cov, rp, nil
-	} else {
-		log.Printf("parse as Go coverage: %s", err)
-		errs = append(errs, fmt.Errorf("go coverage: %w", err))
-	}
-	// lcov
-	if cov, rp, err := coverage.NewLcov().ParseReport(path); err == nil {
-		return cov, rp, nil
-	} else {
-		log.Printf("parse as LCOV: %s", err)
-		errs = append(errs, fmt.Errorf("lcov: %w", err))
-	}
-	// simplecov
-	if cov, rp, err := coverage.NewSimplecov().ParseReport(path); err == nil {
-		return cov, rp, nil
-	} else {
-		log.Printf("parse as SimpleCov: %s", err)
-		errs = append(errs, fmt.Errorf("simpleCov: %w", err))
-	}
-	// clover
-	if cov, rp, err := coverage.NewClover().ParseReport(path); err == nil {
-		return cov, rp, nil
-	} else {
-		log.Printf("parse as Clover: %s", err)
-		errs = append(errs, fmt.Errorf("clover: %w", err))
-	}
-	// cobertura
-	if cov, rp, err := coverage.NewCobertura().ParseReport(path); err == nil {
-		return cov, rp, nil
-	} else {
-		log.Printf("parse as Cobertura: %s", err)
-		errs = append(errs, fmt.Errorf("cobertura: %w", err))
+type parseResult struct {
+	cov *coverage.Coverage
+	rp  string
+	err error
+}
+
+// parseReports parses the coverage reports with at most n workers.
+// The results are sent in the order of paths as soon as the preceding ones are done.
+// A report is not started until the result n reports before it has been received,
+// so at most n parsed coverages are held at a time however slow the receiver is.
+func parseReports(paths, formats, labels []string, n int, aggregateLines bool) <-chan parseResult {
+	results := make([]chan parseResult, len(paths))
+	for i := range results {
+		results[i] = make(chan parseResult, 1)
+	}
+	workers := min(max(n, 1), len(paths))
+	slots := make(chan struct{}, workers)
+	jobs := make(chan int)
+	for range workers {
+		go func() {
+			for i := range jobs {
+				b := coverage.NewBuilder(coverage.AggregateLines(aggregateLines), coverage.Label(labels[i]))
+				rp, err := parseReport(paths[i], formats[i], b)
+				if err != nil {
+					results[i] <- parseResult{err: err}
+					continue
+				}
+				cov, err := b.Coverage()
+				results[i] <- parseResult{cov: cov, rp: rp, err: err}
+			}
+		}()
 	}
-	// jacoco
-	if cov, rp, err := coverage.NewJacoco().ParseReport(path); err == nil {
-		return cov, rp, nil
-	} else {
-		log.Printf("parse as JaCoCo: %s", err)
-		errs = append(errs, fmt.Errorf("jacoco: %w", err))
+	go func() {
+		for i := range paths {
+			slots <- struct{}{}
+			jobs <- i
+		}
+		close(jobs)
+	}()
+	ordered := make(chan parseResult)
+	go func() {
+		for _, ch := range results {
+			ordered <- <-ch
+			<-slots
+		}
+		close(ordered)
+	}()
+	return ordered
+}
+
+func challengeParseReport(path string, b *coverage.Builder) (string, error) {
+	var errs []error
+	for _, p := range coverage.Processors() {
+		rp, n, err := parseReportWith(p, path, b)
+		if err == nil {
+			return rp, nil
+		}
+		if n > 0 {
+			// The report is of the format but broken midway.
+			return "", fmt.Errorf("%s: %w", p.Name(), err)
+		}
+		log.Printf("parse as %s: %s", p.Name(), err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
 	}
 
 	msg := fmt.Sprintf("parsable coverage report not found: %s", path)
 	log.Println(msg)
 
-	return nil, "", fmt.Errorf("%s: %w", msg, errors.Join(errs...))
+	return "", fmt.Errorf("%s: %w", msg, errors.Join(errs...))
+}
+
+// parseReport parses the coverage report into the builder with the processor of the format, or detects the format if it is empty.
+func parseReport(path, format string, b *coverage.Builder) (string, error) {
+	if format == "" {
+		return challengeParseReport(path, b)
+	}
+	p, err := coverage.LookupProcessor(format)
+	if err != nil {
+		return "", err
+	}
+	rp, _, err := parseReportWith(p, path, b)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", p.Name(), err)
+	}
+	return rp, nil
+}
+
+// parseReportWith parses the coverage report into the builder with the processor.
+// Processors that can stream hand over files one by one, so the files parsed before an error stay in the builder;
+// the number of them is returned.
+func parseReportWith(p coverage.Processor, path string, b *coverage.Builder) (string, int, error) {
+	sp, ok := p.(coverage.StreamProcessor)
+	if !ok {
+		cov, rp, err := p.ParseReport(path)
+		if err != nil {
+			return "", 0, err
+		}
+		b.Add(cov)
+		return rp, len(cov.Files), nil
+	}
+	n := 0
+	rp, err := sp.StreamReport(path, func(fc *coverage.FileCoverage) error {
+		b.AddFile(p.Name(), fc)
+		n++
+		return nil
+	})
+	return rp, n, err
 }
 
 // floor1 round down to one decimal place.
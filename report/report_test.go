@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -149,6 +150,417 @@ func TestMeasureCoverage(t *testing.T) {
 	}
 }
 
+func TestMeasureCoverageWithFormat(t *testing.T) {
+	log.SetOutput(io.Discard) // Disable log in challengeParseReport()
+
+	jacoco := filepath.Join(coverageTestdataDir(t), "jacoco", "jacocoTestReport.xml")
+	tests := []struct {
+		format     string
+		wantFormat string
+		wantErr    bool
+	}{
+		{"", "JaCoCo", false},
+		{"jacoco", "JaCoCo", false},
+		{"lcov", "", true},
+		{"unknown", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			r, err := New("owner/repo", Formats(map[string]string{jacoco: tt.format}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := r.MeasureCoverage([]string{jacoco}, nil); err != nil {
+				if !tt.wantErr {
+					t.Error(err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Error("want error")
+			}
+			if got := r.Coverage.Format; got != tt.wantFormat {
+				t.Errorf("got %v\nwant %v", got, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestMeasureCoverageAggregateLines(t *testing.T) {
+	log.SetOutput(io.Discard) // Disable log in challengeParseReport()
+
+	gocover := filepath.Join(coverageTestdataDir(t), "gocover", "coverage.out")
+	lcov := filepath.Join(coverageTestdataDir(t), "lcov", "lcov.info")
+	paths := []string{gocover, lcov, lcov}
+
+	want, err := New("owner/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := want.MeasureCoverage(paths, nil); err != nil {
+		t.Fatal(err)
+	}
+	got, err := New("owner/repo", AggregateLines(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.MeasureCoverage(paths, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got.Coverage.Total != want.Coverage.Total {
+		t.Errorf("got %v\nwant %v", got.Coverage.Total, want.Coverage.Total)
+	}
+	if got.Coverage.Covered != want.Coverage.Covered {
+		t.Errorf("got %v\nwant %v", got.Coverage.Covered, want.Coverage.Covered)
+	}
+	for _, fc := range got.Coverage.Files {
+		if len(fc.Blocks) != fc.Total {
+			t.Errorf("%s: got %v blocks\nwant %v", fc.File, len(fc.Blocks), fc.Total)
+		}
+	}
+}
+
+func TestMeasureCoverageComponents(t *testing.T) {
+	log.SetOutput(io.Discard) // Disable log in challengeParseReport()
+
+	lcov := filepath.Join(coverageTestdataDir(t), "lcov", "lcov.info")
+	r, err := New("owner/repo", Components(map[string][]string{
+		"all":  {"**"},
+		"cli":  {"**/cli/**"},
+		"rest": {"**", "!**/cli/**"},
+		"none": {"none/**"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.MeasureCoverage([]string{lcov}, nil); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, c := range r.Components {
+		names = append(names, c.Name)
+	}
+	if want := []string{"all", "cli", "none", "rest"}; !cmp.Equal(names, want) {
+		t.Errorf("got %v\nwant %v", names, want)
+	}
+	all := r.findComponentByName("all")
+	if all.Total != r.Coverage.Total || all.Covered != r.Coverage.Covered {
+		t.Errorf("got %d/%d\nwant %d/%d", all.Covered, all.Total, r.Coverage.Covered, r.Coverage.Total)
+	}
+	cli := r.findComponentByName("cli")
+	rest := r.findComponentByName("rest")
+	if cli.Total == 0 || cli.Total+rest.Total != all.Total || cli.Covered+rest.Covered != all.Covered {
+		t.Errorf("got cli %d/%d, rest %d/%d\nwant the sum %d/%d", cli.Covered, cli.Total, rest.Covered, rest.Total, all.Covered, all.Total)
+	}
+	if r.IsMeasuredComponentCoverage("none") {
+		t.Error("want not measured")
+	}
+	if r.IsMeasuredComponentCoverage("unknown") {
+		t.Error("want not measured")
+	}
+	if got, want := r.ComponentCoveragePercent("all"), r.CoveragePercent(); got != want {
+		t.Errorf("got %v\nwant %v", got, want)
+	}
+}
+
+func TestMeasureCoverageIgnorePragmas(t *testing.T) {
+	r, err := New("owner/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.MeasureCoverage([]string{filepath.Join(testdataDir(t), "ignore", "lcov.info")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	// Lines 6, 8-11 and 13 are ignored.
+	if want := 4; r.Coverage.Total != want {
+		t.Errorf("got %v\nwant %v", r.Coverage.Total, want)
+	}
+	if want := 4; r.Coverage.Covered != want {
+		t.Errorf("got %v\nwant %v", r.Coverage.Covered, want)
+	}
+}
+
+func TestMeasureCoverageExcludeGenerated(t *testing.T) {
+	lcov := filepath.Join(testdataDir(t), "generated", "lcov.info")
+	tests := []struct {
+		excludeGenerated bool
+		wantTotal        int
+		wantGenerated    []*coverage.GeneratedFile
+	}{
+		{false, 2, nil},
+		{true, 1, []*coverage.GeneratedFile{
+			{File: "testdata/generated/mock.go.txt", Reason: coverage.GeneratedReasonHeader},
+		}},
+	}
+	for _, tt := range tests {
+		r, err := New("owner/repo", ExcludeGenerated(tt.excludeGenerated))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.MeasureCoverage([]string{lcov}, nil); err != nil {
+			t.Fatal(err)
+		}
+		if r.Coverage.Total != tt.wantTotal {
+			t.Errorf("got %v\nwant %v", r.Coverage.Total, tt.wantTotal)
+		}
+		if diff := cmp.Diff(tt.wantGenerated, r.GeneratedFiles()); diff != "" {
+			t.Error(diff)
+		}
+	}
+}
+
+func TestMeasureCoverageInclude(t *testing.T) {
+	lcov := filepath.Join(testdataDir(t), "include", "lcov.info")
+	tests := []struct {
+		include     []string
+		wantTotal   int
+		wantCovered int
+		wantFiles   int
+	}{
+		{nil, 3, 3, 1},
+		{[]string{"testdata/include/*.py"}, 5, 3, 2},
+		{[]string{"testdata/include/*.py", "!testdata/include/untested.py"}, 3, 3, 1},
+	}
+	for _, tt := range tests {
+		r, err := New("owner/repo", Include(tt.include))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.MeasureCoverage([]string{lcov}, nil); err != nil {
+			t.Fatal(err)
+		}
+		if r.Coverage.Total != tt.wantTotal {
+			t.Errorf("got %v\nwant %v", r.Coverage.Total, tt.wantTotal)
+		}
+		if r.Coverage.Covered != tt.wantCovered {
+			t.Errorf("got %v\nwant %v", r.Coverage.Covered, tt.wantCovered)
+		}
+		if len(r.Coverage.Files) != tt.wantFiles {
+			t.Errorf("got %v\nwant %v", len(r.Coverage.Files), tt.wantFiles)
+		}
+	}
+}
+
+func TestMeasureCoverageIncludeGo(t *testing.T) {
+	profile := filepath.Join(testdataDir(t), "include", "go", "coverage.out")
+	r, err := New("owner/repo", Include([]string{"testdata/include/go/*.go"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.MeasureCoverage([]string{profile}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := 2; len(r.Coverage.Files) != want {
+		t.Fatalf("got %v\nwant %v", len(r.Coverage.Files), want)
+	}
+	fc, err := r.Coverage.Files.FindByFile("testdata/include/go/untested.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := coverage.TypeStmt; fc.Type != want {
+		t.Errorf("got %v\nwant %v", fc.Type, want)
+	}
+	lcs := fc.ToLineCoverages()
+	if len(lcs) != fc.Total {
+		t.Errorf("got %v\nwant %v", len(lcs), fc.Total)
+	}
+	for _, lc := range lcs {
+		if lc.Count != 0 || lc.Partial {
+			t.Errorf("line %d: got %v (partial %v)\nwant uncovered", lc.Line, lc.Count, lc.Partial)
+		}
+	}
+	if want := 1; r.Coverage.Covered != want {
+		t.Errorf("got %v\nwant %v", r.Coverage.Covered, want)
+	}
+	if want := 1 + fc.Total; r.Coverage.Total != want {
+		t.Errorf("got %v\nwant %v", r.Coverage.Total, want)
+	}
+	d := r.Compare(&Report{Coverage: &coverage.Coverage{}})
+	if d.Coverage == nil {
+		t.Error("got nil\nwant the diff of the coverage")
+	}
+}
+
+func TestMeasureCoverageSuites(t *testing.T) {
+	unit := filepath.Join(testdataDir(t), "suites", "unit.info")
+	e2e := filepath.Join(testdataDir(t), "suites", "e2e.info")
+	for _, aggregate := range []bool{false, true} {
+		r, err := New("owner/repo", Labels(map[string]string{unit: "unit", e2e: "e2e"}), AggregateLines(aggregate))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := r.MeasureCoverage([]string{unit, e2e}, nil); err != nil {
+			t.Fatal(err)
+		}
+		if want := 75.0; r.CoveragePercent() != want {
+			t.Errorf("got %v\nwant %v", r.CoveragePercent(), want)
+		}
+		want := []*SuiteCoverage{
+			{Label: "e2e", Total: 4, Covered: 1},
+			{Label: "unit", Total: 4, Covered: 2},
+		}
+		if diff := cmp.Diff(want, r.Suites); diff != "" {
+			t.Error(diff)
+		}
+		if got := r.Table(); !strings.Contains(got, "Coverage by e2e") || !strings.Contains(got, "Coverage by unit") {
+			t.Errorf("got\n%v\nwant to contain the coverage by the suites", got)
+		}
+
+		prev := &Report{Suites: []*SuiteCoverage{{Label: "unit", Total: 4, Covered: 1}}}
+		d := r.Compare(prev)
+		if len(d.Suites) != 2 {
+			t.Fatalf("got %v\nwant %v", len(d.Suites), 2)
+		}
+		if d.Suites[0].B != nil {
+			t.Errorf("got %v\nwant %v", *d.Suites[0].B, nil)
+		}
+		if want := 25.0; d.Suites[1].Diff != want {
+			t.Errorf("got %v\nwant %v", d.Suites[1].Diff, want)
+		}
+		if got := d.Table(); !strings.Contains(got, "| **Coverage by unit**") || !strings.Contains(got, "+ | Coverage by unit") {
+			t.Errorf("got\n%v\nwant to contain the coverage by the suites", got)
+		}
+	}
+}
+
+func TestCarryForward(t *testing.T) {
+	prev := &Report{
+		Coverage: &coverage.Coverage{
+			Type: coverage.TypeLOC,
+			Files: coverage.FileCoverages{
+				{File: "api/handler.go", Type: coverage.TypeLOC, Total: 10, Covered: 8},
+				{File: "web/app.ts", Type: coverage.TypeLOC, Total: 4, Covered: 1},
+			},
+		},
+	}
+	r, err := New("owner/repo", Components(map[string][]string{"api": {"api/**"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Coverage = &coverage.Coverage{
+		Type: coverage.TypeLOC,
+		Files: coverage.FileCoverages{
+			{File: "web/app.ts", Type: coverage.TypeLOC, Blocks: coverage.BlockCoverages{newLineBlock(1, 1)}},
+		},
+	}
+	if err := r.CarryForward(prev, []string{"api/**", "web/**"}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"api/**"}, r.CarriedForward()); diff != "" {
+		t.Error(diff)
+	}
+	if want := 11; r.Coverage.Total != want {
+		t.Errorf("got %v\nwant %v", r.Coverage.Total, want)
+	}
+	if want := 80.0; r.ComponentCoveragePercent("api") != want {
+		t.Errorf("got %v\nwant %v", r.ComponentCoveragePercent("api"), want)
+	}
+	if got := r.Table(); !strings.Contains(got, "The coverage of `api/**` is carried forward from the previous report.") {
+		t.Errorf("got\n%v\nwant to contain the note", got)
+	}
+	got := r.FileCoveragesTable([]*gh.PullRequestFile{
+		{Filename: "api/handler.go", BlobURL: "https://github.com/owner/repo/blob/hash/api/handler.go"},
+		{Filename: "web/app.ts", BlobURL: "https://github.com/owner/repo/blob/hash/web/app.ts"},
+	})
+	if !strings.Contains(got, "[api/handler.go](https://github.com/owner/repo/blob/hash/api/handler.go) (carried forward)") {
+		t.Errorf("got\n%v\nwant to mark api/handler.go", got)
+	}
+	if strings.Contains(got, "app.ts) (carried forward)") {
+		t.Errorf("got\n%v\nwant not to mark web/app.ts", got)
+	}
+
+	// All of the groups are carried forward when no coverage is measured.
+	r2, err := New("owner/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r2.CarryForward(prev, []string{"api/**", "web/**"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := 14; r2.Coverage.Total != want {
+		t.Errorf("got %v\nwant %v", r2.Coverage.Total, want)
+	}
+}
+
+func TestMeasureCoverageConcurrency(t *testing.T) {
+	log.SetOutput(io.Discard) // Disable log in challengeParseReport()
+
+	paths := []string{
+		filepath.Join(coverageTestdataDir(t), "lcov", "lcov.info"),
+		filepath.Join(coverageTestdataDir(t), "gocover", "coverage.out"),
+		filepath.Join(coverageTestdataDir(t), "lcov_branch", "branch.lcov"),
+		filepath.Join(coverageTestdataDir(t), "cobertura", "coverage.xml"),
+		filepath.Join(coverageTestdataDir(t), "lcov", "lcov.info"),
+		filepath.Join(coverageTestdataDir(t), "simplecov", ".resultset.json"),
+		filepath.Join(coverageTestdataDir(t), "notfound"),
+	}
+	want, err := New("owner/repo", Concurrency(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := want.MeasureCoverage(paths, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{2, 4, 0} {
+		t.Run(fmt.Sprintf("%d", n), func(t *testing.T) {
+			got, err := New("owner/repo", Concurrency(n))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := got.MeasureCoverage(paths, nil); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got.covPaths, want.covPaths); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(got.Coverage, want.Coverage, cmpopts.IgnoreUnexported(coverage.FileCoverage{})); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func BenchmarkMeasureCoverage(b *testing.B) {
+	log.SetOutput(io.Discard) // Disable log in challengeParseReport()
+
+	// Partial profiles of a matrix build covering the same files.
+	const (
+		reports = 40
+		files   = 100
+		lines   = 100
+	)
+	dir := b.TempDir()
+	var paths []string
+	for n := range reports {
+		var buf bytes.Buffer
+		for i := range files {
+			fmt.Fprintf(&buf, "SF:src/file_%d.js\n", i)
+			for l := 1; l <= lines; l++ {
+				fmt.Fprintf(&buf, "DA:%d,%d\n", l, (l+n)%3)
+			}
+			buf.WriteString("end_of_record\n")
+		}
+		p := filepath.Join(dir, fmt.Sprintf("lcov_%d.info", n))
+		if err := os.WriteFile(p, buf.Bytes(), 0600); err != nil {
+			b.Fatal(err)
+		}
+		paths = append(paths, p)
+	}
+	for _, n := range []int{1, 4, 0} {
+		b.Run(fmt.Sprintf("concurrency=%d", n), func(b *testing.B) {
+			for b.Loop() {
+				r, err := New("owner/repo", Concurrency(n))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := r.MeasureCoverage(paths, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func TestCollectCustomMetrics(t *testing.T) {
 	tests := []struct {
 		envs    map[string]string
@@ -365,6 +777,46 @@ func TestTable(t *testing.T) {
 	}
 }
 
+func TestTableWithBranchAndFunctionCoverage(t *testing.T) {
+	r := &Report{
+		Coverage: &coverage.Coverage{
+			Total:           10,
+			Covered:         8,
+			BranchTotal:     4,
+			BranchCovered:   1,
+			FunctionTotal:   3,
+			FunctionCovered: 2,
+		},
+	}
+	want := `| Coverage | Branch Coverage | Function Coverage |
+|---------:|----------------:|------------------:|
+| 80.0%    | 25.0%           | 66.6%             |
+`
+	if got := r.Table(); got != want {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestTableWithComponents(t *testing.T) {
+	r := &Report{
+		Coverage: &coverage.Coverage{
+			Total:   10,
+			Covered: 8,
+		},
+		Components: []*ComponentCoverage{
+			{Name: "api", Total: 4, Covered: 3},
+			{Name: "web", Total: 6, Covered: 5},
+		},
+	}
+	want := `| Coverage | Coverage (api) | Coverage (web) |
+|---------:|---------------:|---------------:|
+| 80.0%    | 75.0%          | 83.3%          |
+`
+	if got := r.Table(); got != want {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+}
+
 func TestOut(t *testing.T) {
 	tests := []struct {
 		path string
@@ -425,6 +877,63 @@ func TestFileCoveragesTable(t *testing.T) {
 	}
 }
 
+func TestMeasurePatchCoverage(t *testing.T) {
+	r := &Report{
+		Coverage: &coverage.Coverage{
+			Total:   5,
+			Covered: 3,
+			Files: coverage.FileCoverages{
+				{
+					File:    "a.go",
+					Type:    coverage.TypeLOC,
+					Total:   3,
+					Covered: 2,
+					Blocks: coverage.BlockCoverages{
+						newLineBlock(1, 1),
+						newLineBlock(2, 0),
+						newLineBlock(3, 1),
+					},
+				},
+				{
+					File:    "b.go",
+					Type:    coverage.TypeLOC,
+					Total:   2,
+					Covered: 1,
+					Blocks: coverage.BlockCoverages{
+						newLineBlock(1, 1),
+						newLineBlock(2, 0),
+					},
+				},
+			},
+		},
+	}
+	files := []*gh.PullRequestFile{
+		{Filename: "a.go", BlobURL: "https://github.com/owner/repo/blob/xxx/a.go", ChangedLines: []int{2, 3, 4}},
+		{Filename: "b.go", BlobURL: "https://github.com/owner/repo/blob/xxx/b.go"},
+		{Filename: "README.md", ChangedLines: []int{1}},
+	}
+	r.MeasurePatchCoverage(files)
+	if !r.IsMeasuredPatchCoverage() {
+		t.Fatal("want measured")
+	}
+	if want := 50.0; r.PatchCoveragePercent() != want {
+		t.Errorf("got %v\nwant %v", r.PatchCoveragePercent(), want)
+	}
+	if got, want := r.Table(), "| Patch Coverage |"; !strings.Contains(got, want) {
+		t.Errorf("got\n%v\nwant to contain %q", got, want)
+	}
+	want := `### Code coverage of files in pull request scope (60.0%)
+
+|                        Files                        | Coverage | Patch |
+|-----------------------------------------------------|---------:|------:|
+| [a.go](https://github.com/owner/repo/blob/xxx/a.go) | 66.6%    | 50.0% |
+| [b.go](https://github.com/owner/repo/blob/xxx/b.go) | 50.0%    | -     |
+`
+	if got := r.FileCoveragesTable(files); got != want {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+}
+
 func TestMergeExecutionTimes(t *testing.T) {
 	tests := []struct {
 		steps []gh.Step
@@ -538,6 +1047,33 @@ func TestCompare(t *testing.T) {
 	}
 }
 
+func TestCompareComponents(t *testing.T) {
+	a := &Report{
+		Components: []*ComponentCoverage{
+			{Name: "api", Total: 4, Covered: 3},
+			{Name: "web", Total: 10, Covered: 5},
+		},
+	}
+	b := &Report{
+		Components: []*ComponentCoverage{
+			{Name: "api", Total: 4, Covered: 2},
+		},
+	}
+	got := a.Compare(b).Components
+	if len(got) != 2 {
+		t.Fatalf("got %v\nwant %v", len(got), 2)
+	}
+	if want := 25.0; got[0].Diff != want {
+		t.Errorf("got %v\nwant %v", got[0].Diff, want)
+	}
+	if got[1].B != nil {
+		t.Errorf("got %v\nwant %v", *got[1].B, nil)
+	}
+	if want := 50.0; got[1].Diff != want {
+		t.Errorf("got %v\nwant %v", got[1].Diff, want)
+	}
+}
+
 func TestValidate(t *testing.T) {
 	tests := []struct {
 		r    *Report
@@ -624,3 +1160,13 @@ func TestConvertFormat(t *testing.T) {
 		})
 	}
 }
+
+func newLineBlock(line, count int) *coverage.BlockCoverage {
+	c := coverage.ExecCount(count)
+	return &coverage.BlockCoverage{
+		Type:      coverage.TypeLOC,
+		StartLine: &line,
+		EndLine:   &line,
+		Count:     &c,
+	}
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"fmt"
+)
+
+// SuiteCoverage is the code coverage by a test suite, the coverage reports of a label.
+type SuiteCoverage struct {
+	Label   string `json:"label"`
+	Total   int    `json:"total"`
+	Covered int    `json:"covered"`
+}
+
+type DiffSuiteCoverage struct {
+	Label  string         `json:"label"`
+	A      float64        `json:"a"`
+	B      *float64       `json:"b"`
+	Diff   float64        `json:"diff"`
+	SuiteA *SuiteCoverage `json:"-"`
+	SuiteB *SuiteCoverage `json:"-"`
+}
+
+func (s *SuiteCoverage) Percent() float64 {
+	if s == nil || s.Total == 0 {
+		return 0.0
+	}
+	return float64(s.Covered) / float64(s.Total) * 100
+}
+
+// Title returns the title of the test suite used in tables.
+func (s *SuiteCoverage) Title() string {
+	return suiteTitle(s.Label)
+}
+
+func (s *SuiteCoverage) Compare(s2 *SuiteCoverage) *DiffSuiteCoverage {
+	d := &DiffSuiteCoverage{
+		Label:  s.Label,
+		A:      s.Percent(),
+		SuiteA: s,
+		SuiteB: s2,
+	}
+	if s2 != nil {
+		b := s2.Percent()
+		d.B = &b
+	}
+	d.Diff = d.A
+	if d.B != nil {
+		d.Diff = d.A - *d.B
+	}
+	return d
+}
+
+// measureSuites measures the code coverage by each test suite against all of the code.
+func (r *Report) measureSuites() {
+	r.Suites = nil
+	for _, l := range r.Coverage.Suites() {
+		r.Suites = append(r.Suites, &SuiteCoverage{
+			Label:   l,
+			Total:   r.Coverage.Total,
+			Covered: r.Coverage.SuiteCovered(l),
+		})
+	}
+}
+
+func (r *Report) findSuiteByLabel(label string) *SuiteCoverage {
+	if r == nil {
+		return nil
+	}
+	for _, s := range r.Suites {
+		if s.Label == label {
+			return s
+		}
+	}
+	return nil
+}
+
+func suiteTitle(label string) string {
+	return fmt.Sprintf("Coverage by %s", label)
+}
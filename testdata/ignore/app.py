@@ -0,0 +1,13 @@
+import sys
+
+
+def main(args):
+    if not args:
+        sys.exit(1)  # octocov:ignore
+    print(args)
+    # octocov:ignore-start
+    if args[0] == "debug":
+        print("debug")
+    # octocov:ignore-end
+    # octocov:ignore-next-line
+    return 0
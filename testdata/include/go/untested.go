@@ -0,0 +1,7 @@
+package app
+
+// Sub is not called by any test.
+func Sub(a, b int) int {
+	c := a - b
+	return c
+}
@@ -0,0 +1,4 @@
+# Not loaded by any test.
+def sub(a, b):
+    """Subtract b from a."""
+    return a - b